@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/0mykull/gitty/internal/ai"
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// commands are the actions that can run without the menu, e.g. `gitty push`
+var commands = map[string]func(cfg *config.Config, args []string) error{
+	"commit":  runCommit,
+	"push":    runPush,
+	"pull":    runPull,
+	"publish": runPublish,
+	"status":  runStatus,
+}
+
+// runCommand runs a subcommand and reports its outcome on stdout
+func runCommand(cfg *config.Config, args []string) error {
+	run, ok := commands[args[0]]
+	if !ok {
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown command %q (available: %s)", args[0], strings.Join(names, ", "))
+	}
+	return run(cfg, args[1:])
+}
+
+func runCommit(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("commit", flag.ExitOnError)
+	message := fs.String("m", "", "commit message; generated with AI when empty")
+	all := fs.Bool("a", false, "stage all changes first")
+	noVerify := fs.Bool("no-verify", false, "skip pre-commit and commit-msg hooks")
+	fs.Parse(args)
+
+	if *all {
+		if err := git.AddAll(); err != nil {
+			return fmt.Errorf("failed to stage changes: %w", err)
+		}
+	}
+	if !git.HasStagedChanges() {
+		return fmt.Errorf("no staged changes to commit")
+	}
+
+	msg := strings.TrimSpace(*message)
+	if msg == "" {
+		diff, err := git.GetDiff()
+		if err != nil {
+			return err
+		}
+		msg, err = ai.GenerateCommitMessage(diff, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to generate commit message: %w", err)
+		}
+		msg, _ = ai.LimitBody(msg, cfg.AI.MaxBodyLines)
+	}
+
+	if err := git.Commit(msg, git.CommitOptions{Sign: cfg.Git.SignCommits, NoVerify: *noVerify}); err != nil {
+		return err
+	}
+	fmt.Printf("%s Committed: %s\n", styles.Icons.Check, strings.SplitN(msg, "\n", 2)[0])
+	return nil
+}
+
+func runPush(_ *config.Config, args []string) error {
+	fs := flag.NewFlagSet("push", flag.ExitOnError)
+	fs.Parse(args)
+
+	if err := git.Push(); err != nil {
+		return err
+	}
+	fmt.Printf("%s Pushed to remote\n", styles.Icons.Check)
+	return nil
+}
+
+func runPull(_ *config.Config, args []string) error {
+	fs := flag.NewFlagSet("pull", flag.ExitOnError)
+	fs.Parse(args)
+
+	if err := git.Pull(); err != nil {
+		return err
+	}
+	fmt.Printf("%s Pulled from remote\n", styles.Icons.Check)
+	return nil
+}
+
+func runPublish(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("publish", flag.ExitOnError)
+	name := fs.String("name", git.GetRepoName(), "repository name")
+	private := fs.Bool("private", cfg.GitHub.DefaultVisibility == "private", "create a private repository")
+	description := fs.String("description", "", "repository description")
+	message := fs.String("m", "Initial commit", "commit message used with --all")
+	var all bool
+	fs.BoolVar(&all, "a", false, "stage and commit every change first, untracked files included")
+	fs.BoolVar(&all, "all", false, "same as -a")
+	fs.Parse(args)
+
+	if !git.HasGh() {
+		return fmt.Errorf("publish needs the GitHub CLI (gh)")
+	}
+	if git.HasRemote("origin") {
+		return fmt.Errorf("origin already exists, use gitty push instead")
+	}
+	if !git.IsRepo() {
+		if err := git.Init(cfg.Git.DefaultBranch); err != nil {
+			return fmt.Errorf("failed to initialize git: %w", err)
+		}
+	}
+
+	// Untracked files may be secrets or build output, so only commit them
+	// when asked to
+	status, err := git.GetStatus()
+	if err != nil {
+		return err
+	}
+	if !all && (status.HasStaged || status.HasUnstaged || status.HasUntracked) {
+		return fmt.Errorf("uncommitted changes, commit them first or pass --all to commit everything")
+	}
+	if all {
+		if cfg.Git.UserName != "" && cfg.Git.UserEmail != "" {
+			git.SetUser(cfg.Git.UserName, cfg.Git.UserEmail)
+		}
+		if err := git.AddAll(); err != nil {
+			return fmt.Errorf("failed to stage changes: %w", err)
+		}
+		if git.HasStagedChanges() {
+			if err := git.Commit(strings.TrimSpace(*message), git.CommitOptions{Sign: cfg.Git.SignCommits}); err != nil {
+				return fmt.Errorf("failed to commit: %w", err)
+			}
+		}
+	}
+	// gh has nothing to push without a commit
+	if _, err := git.HeadCommit(); err != nil {
+		return fmt.Errorf("nothing to publish, commit some files first")
+	}
+
+	visibility := "public"
+	if *private {
+		visibility = "private"
+	}
+	if err := git.CreateGitHubRepo(*name, visibility, *description); err != nil {
+		return err
+	}
+
+	url, _ := git.GetGitHubURL()
+	fmt.Printf("%s Published %s\n", styles.Icons.Check, url)
+	return nil
+}
+
+func runStatus(_ *config.Config, args []string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the status as JSON")
+	fs.Parse(args)
+
+	status, err := git.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		// Empty lists rather than null keep consumers like jq simple
+		for _, files := range []*[]string{&status.StagedFiles, &status.ModifiedFiles, &status.UntrackedFiles, &status.ConflictedFiles} {
+			if *files == nil {
+				*files = []string{}
+			}
+		}
+		out, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	if !status.IsRepo {
+		return fmt.Errorf("not a git repository")
+	}
+	fmt.Printf("%s  +%d ~%d ?%d  ↑%d ↓%d\n", status.Branch,
+		len(status.StagedFiles), len(status.ModifiedFiles), len(status.UntrackedFiles),
+		status.Ahead, status.Behind)
+	return nil
+}
+
+// exitOnError prints err the way the TUI-less commands report failures
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", styles.Icons.Cross, err)
+		os.Exit(1)
+	}
+}
@@ -1,20 +1,27 @@
 package ai
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
 )
 
 const (
 	OpenAIURL    = "https://api.openai.com/v1/chat/completions"
 	AnthropicURL = "https://api.anthropic.com/v1/messages"
+	GeminiURL    = "https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s"
 )
 
 // OpenAI types
@@ -27,6 +34,18 @@ type openAIRequest struct {
 	Model       string          `json:"model"`
 	Messages    []openAIMessage `json:"messages"`
 	Temperature float64         `json:"temperature"`
+	N           int             `json:"n,omitempty"`
+	Stream      bool            `json:"stream,omitempty"`
+	MaxTokens   int             `json:"max_tokens,omitempty"`
+}
+
+// openAIStreamChunk is one server-sent event of a streamed completion
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
 }
 
 type openAIResponse struct {
@@ -62,10 +81,260 @@ type anthropicResponse struct {
 	} `json:"error,omitempty"`
 }
 
+// Gemini types
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiRequest struct {
+	SystemInstruction *geminiContent  `json:"systemInstruction,omitempty"`
+	Contents          []geminiContent `json:"contents"`
+	GenerationConfig  struct {
+		Temperature     float64 `json:"temperature"`
+		MaxOutputTokens int     `json:"maxOutputTokens,omitempty"`
+	} `json:"generationConfig"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content geminiContent `json:"content"`
+	} `json:"candidates"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// ErrEmptyDiff is returned when the staged changes give the model nothing to describe
+var ErrEmptyDiff = errors.New("nothing textual to summarize in the staged changes, write the message yourself")
+
+// noAPIKeyError explains where the key for the configured provider can come from
+func noAPIKeyError(cfg *config.Config) error {
+	return fmt.Errorf("API key not configured. Set ai.api_key, ai.api_key_file or ai.api_key_cmd in %s, or the %s env var",
+		config.ConfigPath(), config.APIKeyEnv(cfg.AI.Provider))
+}
+
+// defaultMaxTokens caps a response when ai.max_tokens is unset
+const defaultMaxTokens = 1024
+
+// maxTokens returns the response length limit sent to every provider
+func maxTokens(cfg *config.Config) int {
+	if cfg.AI.MaxTokens > 0 {
+		return cfg.AI.MaxTokens
+	}
+	return defaultMaxTokens
+}
+
+// redactKey masks the API key wherever an error echoes it, e.g. in a
+// request URL or a provider's "invalid key" response
+func redactKey(err error, cfg *config.Config) error {
+	if err == nil || cfg.AI.APIKey == "" || !strings.Contains(err.Error(), cfg.AI.APIKey) {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), cfg.AI.APIKey, config.RedactKey(cfg.AI.APIKey)))
+}
+
 // GenerateCommitMessage generates a commit message from a diff using AI
 func GenerateCommitMessage(diff string, cfg *config.Config) (string, error) {
+	candidates, err := generate(diff, "", 1, cfg)
+	if err != nil {
+		return "", redactKey(err, cfg)
+	}
+	return candidates[0], nil
+}
+
+// GenerateCandidates generates ai.candidates commit messages to choose from,
+// asking for a different phrasing than previous when it is not empty
+func GenerateCandidates(diff, previous string, cfg *config.Config) ([]string, error) {
+	candidates, err := generate(diff, previous, max(cfg.AI.Candidates, 1), cfg)
+	return candidates, redactKey(err, cfg)
+}
+
+func generate(diff, previous string, n int, cfg *config.Config) ([]string, error) {
+	systemPrompt, userPrompt, temperature, err := buildPrompts(diff, previous, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var single func(systemPrompt, userPrompt string, temperature float64, cfg *config.Config) (string, error)
+	switch cfg.AI.Provider {
+	case "anthropic":
+		single = generateAnthropicCommit
+	case "gemini":
+		single = generateGeminiCommit
+	default:
+		// OpenAI returns several choices from one request
+		return generateOpenAICommits(systemPrompt, userPrompt, temperature, n, cfg)
+	}
+
+	// Other providers answer once per request
+	candidates := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		msg, err := single(systemPrompt, userPrompt, temperature, cfg)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, msg)
+	}
+	return candidates, nil
+}
+
+// GeneratePRDescription drafts a markdown pull request description from the
+// commits of a branch, given newest first as returned by git.CommitsSince
+func GeneratePRDescription(commits []git.CommitInfo, cfg *config.Config) (string, error) {
+	description, err := generatePRDescription(commits, cfg)
+	return description, redactKey(err, cfg)
+}
+
+func generatePRDescription(commits []git.CommitInfo, cfg *config.Config) (string, error) {
 	if cfg.AI.APIKey == "" {
-		return "", fmt.Errorf("API key not configured. Set it in ~/.config/gitty/config.yaml or OPENAI_API_KEY env var")
+		return "", noAPIKeyError(cfg)
+	}
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits to describe")
+	}
+
+	systemPrompt := `You are a skilled developer writing a GitHub pull request description.
+Write markdown with:
+1. A "## Summary" section of 1-3 sentences on what the branch does and why.
+2. A "## Changes" section with a bulleted list of the notable changes.
+
+Base it only on the commits provided. Do not invent tests, issues or links.
+IMPORTANT: Return the markdown only. Do NOT wrap it in a code block.`
+
+	language, err := cfg.AI.LanguageFor()
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(language, "English") {
+		systemPrompt += fmt.Sprintf("\nWrite the description in %s.", language)
+	}
+
+	var log strings.Builder
+	for i := len(commits) - 1; i >= 0; i-- {
+		log.WriteString("- " + commits[i].Subject + "\n")
+		if commits[i].Body != "" {
+			log.WriteString("  " + strings.ReplaceAll(commits[i].Body, "\n", "\n  ") + "\n")
+		}
+	}
+	commitLog := log.String()
+	if len(commitLog) > cfg.AI.MaxDiffSize {
+		commitLog = commitLog[:cfg.AI.MaxDiffSize] + "\n...(truncated)"
+	}
+	userPrompt := "Commits on this branch, oldest first:\n\n" + commitLog
+
+	temperature, err := cfg.AI.TemperatureFor(cfg.AI.Provider)
+	if err != nil {
+		return "", err
+	}
+
+	switch cfg.AI.Provider {
+	case "anthropic":
+		return generateAnthropicCommit(systemPrompt, userPrompt, temperature, cfg)
+	case "gemini":
+		return generateGeminiCommit(systemPrompt, userPrompt, temperature, cfg)
+	default:
+		candidates, err := generateOpenAICommits(systemPrompt, userPrompt, temperature, 1, cfg)
+		if err != nil {
+			return "", err
+		}
+		return candidates[0], nil
+	}
+}
+
+// StreamCommitMessage generates one commit message, sending text to chunks as
+// it arrives and closing chunks when done. Providers without streaming support
+// send the whole message at once.
+func StreamCommitMessage(diff, previous string, cfg *config.Config, chunks chan<- string) (string, error) {
+	msg, err := streamCommitMessage(diff, previous, cfg, chunks)
+	return msg, redactKey(err, cfg)
+}
+
+func streamCommitMessage(diff, previous string, cfg *config.Config, chunks chan<- string) (string, error) {
+	defer close(chunks)
+
+	if cfg.AI.Provider == "anthropic" || cfg.AI.Provider == "gemini" {
+		candidates, err := generate(diff, previous, 1, cfg)
+		if err != nil {
+			return "", err
+		}
+		chunks <- candidates[0]
+		return candidates[0], nil
+	}
+
+	systemPrompt, userPrompt, temperature, err := buildPrompts(diff, previous, cfg)
+	if err != nil {
+		return "", err
+	}
+	return streamOpenAICommit(systemPrompt, userPrompt, temperature, cfg, chunks)
+}
+
+// stylePrompts describes how to start the subject line for each ai.style
+var stylePrompts = map[string]string{
+	"":             conventionalPrompt,
+	"conventional": conventionalPrompt,
+
+	"gitmoji": `Start the subject line with the gitmoji that fits the change best:
+- ✨ new feature
+- 🐛 bug fix
+- ♻️ code refactoring
+- 📝 documentation changes
+- 🎨 formatting or structure
+- ✅ adding or updating tests
+- 🔧 configuration changes
+- ⚡️ performance improvements
+- 🔥 removing code or files
+Do not add a conventional commit prefix after the emoji.`,
+
+	"plain": `Write the subject line as a plain imperative sentence, e.g. "Add retry to the sync job".
+Do not use conventional commit prefixes or emoji.`,
+}
+
+const conventionalPrompt = `Use conventional commit prefixes when appropriate:
+- feat: new feature
+- fix: bug fix
+- refactor: code refactoring
+- docs: documentation changes
+- style: formatting changes
+- test: adding tests
+- chore: maintenance tasks`
+
+// maxPromptFiles caps how many staged file names are sent to the model
+const maxPromptFiles = 100
+
+// stagedFilesContext lists staged file names for the user prompt
+func stagedFilesContext(files []string) string {
+	var b strings.Builder
+	b.WriteString("Staged files:")
+	for i, file := range files {
+		if i == maxPromptFiles {
+			fmt.Fprintf(&b, "\n- ...and %d more", len(files)-maxPromptFiles)
+			break
+		}
+		b.WriteString("\n- " + file)
+	}
+	return b.String()
+}
+
+// buildPrompts returns the system and user prompts and the temperature for a diff
+func buildPrompts(diff, previous string, cfg *config.Config) (string, string, float64, error) {
+	if cfg.AI.APIKey == "" {
+		return "", "", 0, noAPIKeyError(cfg)
+	}
+
+	// Mode changes and submodule bumps are staged without a textual diff
+	subject := "this diff"
+	if strings.TrimSpace(diff) == "" {
+		summary, err := git.GetDiffSummary()
+		if err != nil || strings.TrimSpace(summary) == "" {
+			return "", "", 0, ErrEmptyDiff
+		}
+		diff = summary
+		subject = "these staged changes, which have no textual diff (e.g. file mode changes or submodule updates)"
 	}
 
 	// Truncate diff if too long
@@ -73,89 +342,266 @@ func GenerateCommitMessage(diff string, cfg *config.Config) (string, error) {
 		diff = diff[:cfg.AI.MaxDiffSize] + "\n...(truncated)"
 	}
 
+	style, ok := stylePrompts[cfg.AI.Style]
+	if !ok {
+		return "", "", 0, fmt.Errorf("ai.style %q must be conventional, gitmoji or plain", cfg.AI.Style)
+	}
+
 	systemPrompt := `You are a skilled developer writing git commit messages.
 Format the message strictly as follows:
 1. A single concise subject line (max 50 chars) that describes WHAT changed.
 2. A blank line.
 3. A detailed bulleted list of changes explaining WHY and HOW.
 
-Use conventional commit prefixes when appropriate:
-- feat: new feature
-- fix: bug fix
-- refactor: code refactoring
-- docs: documentation changes
-- style: formatting changes
-- test: adding tests
-- chore: maintenance tasks
+` + style + `
 
 IMPORTANT: Return raw text only. Do NOT wrap in markdown code blocks.`
 
-	userPrompt := fmt.Sprintf("Generate a commit message for this diff:\n\n%s", diff)
+	language, err := cfg.AI.LanguageFor()
+	if err != nil {
+		return "", "", 0, err
+	}
+	if !strings.EqualFold(language, "English") {
+		systemPrompt += fmt.Sprintf("\nWrite the subject and body in %s.", language)
+		if cfg.AI.Style == "" || cfg.AI.Style == "conventional" {
+			systemPrompt += " Keep conventional commit prefixes (feat, fix, ...) in English."
+		}
+	}
 
-	switch cfg.AI.Provider {
-	case "anthropic":
-		return generateAnthropicCommit(systemPrompt, userPrompt, cfg)
-	default:
-		return generateOpenAICommit(systemPrompt, userPrompt, cfg)
+	if cfg.AI.MaxBodyLines > 0 {
+		systemPrompt += fmt.Sprintf("\nKeep the body to at most %d bullet points.", cfg.AI.MaxBodyLines)
+	}
+
+	userPrompt := fmt.Sprintf("Generate a commit message for %s:\n\n%s", subject, diff)
+
+	// The file list keeps the full picture when the diff is truncated
+	if status, err := git.GetStatus(); err == nil && len(status.StagedFiles) > 0 {
+		userPrompt = stagedFilesContext(status.StagedFiles) + "\n\n" + userPrompt
+	}
+	if previous != "" {
+		userPrompt += fmt.Sprintf("\n\nYou previously suggested:\n\n%s\n\nGive a different phrasing.", previous)
+	}
+
+	temperature, err := cfg.AI.TemperatureFor(cfg.AI.Provider)
+	if err != nil {
+		return "", "", 0, err
 	}
+
+	return systemPrompt, userPrompt, temperature, nil
 }
 
-func generateOpenAICommit(systemPrompt, userPrompt string, cfg *config.Config) (string, error) {
+func generateOpenAICommits(systemPrompt, userPrompt string, temperature float64, n int, cfg *config.Config) ([]string, error) {
 	reqBody := openAIRequest{
 		Model: cfg.AI.Model,
 		Messages: []openAIMessage{
 			{Role: "system", Content: systemPrompt},
 			{Role: "user", Content: userPrompt},
 		},
-		Temperature: cfg.AI.Temperature,
+		Temperature: temperature,
+		MaxTokens:   maxTokens(cfg),
+	}
+	if n > 1 {
+		reqBody.N = n
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", OpenAIURL, bytes.NewBuffer(jsonBody))
+	endpoint, err := openAIEndpoint(cfg.AI.BaseURL)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+cfg.AI.APIKey)
 
 	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := doWithRetry(client, req, cfg.AI.MaxRetries)
 	if err != nil {
-		return "", fmt.Errorf("API call failed: %w", err)
+		return nil, fmt.Errorf("API call failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
 	}
 
 	var apiResp openAIResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if apiResp.Error != nil {
-		return "", fmt.Errorf("OpenAI error: %s", apiResp.Error.Message)
+		return nil, fmt.Errorf("OpenAI error: %s", apiResp.Error.Message)
 	}
 
 	if len(apiResp.Choices) == 0 {
+		return nil, fmt.Errorf("no response from OpenAI")
+	}
+
+	candidates := make([]string, 0, len(apiResp.Choices))
+	for _, choice := range apiResp.Choices {
+		candidates = append(candidates, cleanMarkdown(strings.TrimSpace(choice.Message.Content)))
+	}
+
+	return candidates, nil
+}
+
+func streamOpenAICommit(systemPrompt, userPrompt string, temperature float64, cfg *config.Config, chunks chan<- string) (string, error) {
+	reqBody := openAIRequest{
+		Model: cfg.AI.Model,
+		Messages: []openAIMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: userPrompt},
+		},
+		Temperature: temperature,
+		Stream:      true,
+		MaxTokens:   maxTokens(cfg),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint, err := openAIEndpoint(cfg.AI.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cfg.AI.APIKey)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := doWithRetry(client, req, cfg.AI.MaxRetries)
+	if err != nil {
+		return "", fmt.Errorf("API call failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Each event is a "data: {...}" line, ending with "data: [DONE]"
+	var content strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", fmt.Errorf("failed to parse stream: %w", err)
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		content.WriteString(chunk.Choices[0].Delta.Content)
+		chunks <- chunk.Choices[0].Delta.Content
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("stream interrupted: %w", err)
+	}
+
+	if content.Len() == 0 {
 		return "", fmt.Errorf("no response from OpenAI")
 	}
 
-	content := strings.TrimSpace(apiResp.Choices[0].Message.Content)
-	content = cleanMarkdown(content)
+	return cleanMarkdown(strings.TrimSpace(content.String())), nil
+}
 
-	return content, nil
+// doWithRetry sends req, retrying 429 and 5xx responses with exponential
+// backoff and jitter. The client timeout applies to each attempt.
+func doWithRetry(client *http.Client, req *http.Request, maxRetries int) (*http.Response, error) {
+	backoff := 500 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable || attempt >= maxRetries || req.GetBody == nil {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		time.Sleep(backoff + time.Duration(rand.Int63n(int64(backoff/2))))
+		backoff *= 2
+
+		// The body was consumed by the previous attempt
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+}
+
+// openAIEndpoint returns the chat completions URL for an OpenAI-compatible
+// base URL. The API key goes along with every request, so plain http is only
+// accepted for a proxy on this machine.
+func openAIEndpoint(baseURL string) (string, error) {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		return OpenAIURL, nil
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("ai.base_url %q is not a URL", baseURL)
+	}
+	if u.Scheme != "https" && !(u.Scheme == "http" && isLoopback(u.Hostname())) {
+		return "", fmt.Errorf("ai.base_url %q must use https, plain http is only allowed for localhost", baseURL)
+	}
+	if strings.HasSuffix(baseURL, "/chat/completions") {
+		return baseURL, nil
+	}
+	return baseURL + "/chat/completions", nil
+}
+
+// isLoopback reports whether host names this machine
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
 
-func generateAnthropicCommit(systemPrompt, userPrompt string, cfg *config.Config) (string, error) {
+// EndpointHost returns the host a custom ai.base_url sends the API key to, or
+// "" when the provider's own API is used
+func EndpointHost(cfg *config.Config) string {
+	if cfg.AI.Provider != "openai" || strings.TrimSpace(cfg.AI.BaseURL) == "" {
+		return ""
+	}
+	u, err := url.Parse(strings.TrimSpace(cfg.AI.BaseURL))
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+func generateAnthropicCommit(systemPrompt, userPrompt string, temperature float64, cfg *config.Config) (string, error) {
 	model := cfg.AI.Model
 	if !strings.HasPrefix(model, "claude") {
 		model = "claude-3-5-sonnet-20241022"
@@ -163,12 +609,12 @@ func generateAnthropicCommit(systemPrompt, userPrompt string, cfg *config.Config
 
 	reqBody := anthropicRequest{
 		Model:     model,
-		MaxTokens: 1024,
+		MaxTokens: maxTokens(cfg),
 		System:    systemPrompt,
 		Messages: []anthropicMessage{
 			{Role: "user", Content: userPrompt},
 		},
-		Temperature: cfg.AI.Temperature,
+		Temperature: temperature,
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -186,7 +632,7 @@ func generateAnthropicCommit(systemPrompt, userPrompt string, cfg *config.Config
 	req.Header.Set("anthropic-version", "2023-06-01")
 
 	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := doWithRetry(client, req, cfg.AI.MaxRetries)
 	if err != nil {
 		return "", fmt.Errorf("API call failed: %w", err)
 	}
@@ -217,6 +663,104 @@ func generateAnthropicCommit(systemPrompt, userPrompt string, cfg *config.Config
 	return content, nil
 }
 
+func generateGeminiCommit(systemPrompt, userPrompt string, temperature float64, cfg *config.Config) (string, error) {
+	model := cfg.AI.Model
+	if !strings.HasPrefix(model, "gemini") {
+		model = "gemini-1.5-flash"
+	}
+
+	reqBody := geminiRequest{
+		SystemInstruction: &geminiContent{Parts: []geminiPart{{Text: systemPrompt}}},
+		Contents: []geminiContent{
+			{Role: "user", Parts: []geminiPart{{Text: userPrompt}}},
+		},
+	}
+	reqBody.GenerationConfig.Temperature = temperature
+	reqBody.GenerationConfig.MaxOutputTokens = maxTokens(cfg)
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf(GeminiURL, model, cfg.AI.APIKey)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := doWithRetry(client, req, cfg.AI.MaxRetries)
+	if err != nil {
+		// The key is part of the URL, so this is where redactKey matters most
+		return "", fmt.Errorf("API call failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp geminiResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if apiResp.Error != nil {
+		return "", fmt.Errorf("Gemini error: %s", apiResp.Error.Message)
+	}
+
+	if len(apiResp.Candidates) == 0 || len(apiResp.Candidates[0].Content.Parts) == 0 {
+		return "", fmt.Errorf("no response from Gemini")
+	}
+
+	content := strings.TrimSpace(apiResp.Candidates[0].Content.Parts[0].Text)
+	content = cleanMarkdown(content)
+
+	return content, nil
+}
+
+// LimitBody keeps at most maxItems body entries of a commit message, where an
+// entry is a bullet point with its continuation lines or a plain line. It
+// reports whether anything was removed. A limit of 0 means no limit.
+func LimitBody(message string, maxItems int) (string, bool) {
+	if maxItems <= 0 {
+		return message, false
+	}
+
+	subject, body, ok := strings.Cut(message, "\n\n")
+	if !ok {
+		return message, false
+	}
+
+	var kept []string
+	items := 0
+	trimmed := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		isBullet := strings.HasPrefix(trimmedLine, "-") || strings.HasPrefix(trimmedLine, "*") || strings.HasPrefix(trimmedLine, "•")
+		isContinuation := trimmedLine != "" && !isBullet && strings.HasPrefix(line, " ")
+
+		if trimmedLine != "" && !isContinuation {
+			items++
+		}
+		if items > maxItems {
+			trimmed = true
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	if !trimmed {
+		return message, false
+	}
+	return subject + "\n\n" + strings.TrimRight(strings.Join(kept, "\n"), "\n "), true
+}
+
 func cleanMarkdown(content string) string {
 	// Remove markdown code blocks
 	content = strings.ReplaceAll(content, "```markdown", "")
@@ -1,8 +1,17 @@
 package config
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -19,16 +28,110 @@ type Config struct {
 type GitConfig struct {
 	UserName  string `yaml:"user_name"`
 	UserEmail string `yaml:"user_email"`
-	Editor    string `yaml:"editor"`
+
+	// Editor opens commit messages and files, e.g. "code --wait"; empty uses $VISUAL, $EDITOR or vim
+	Editor string `yaml:"editor"`
+
+	// DefaultBranch names the first branch of repos gitty initializes; empty
+	// leaves it to git's init.defaultBranch
+	DefaultBranch string `yaml:"default_branch"`
+
+	// AutoTrackOnCreate pushes new branches and sets their upstream right away
+	AutoTrackOnCreate bool `yaml:"auto_track_on_create"`
+
+	// SignCommits GPG-signs commits (-S) and release tags (-s)
+	SignCommits bool `yaml:"sign_commits"`
+
+	// TicketVerb is preselected when linking a commit to an issue: Closes, Fixes or Refs
+	TicketVerb string `yaml:"ticket_verb"`
+
+	// SafetyStash stashes local changes before reset and rollback so they can
+	// be restored with the git stash apply command gitty prints
+	SafetyStash bool `yaml:"safety_stash"`
+
+	// MaxSubjectLen blocks commits whose subject line is longer; 0 disables it
+	MaxSubjectLen int `yaml:"max_subject_len"`
+
+	// CommandTimeoutMs stops a local git or gh call that runs longer; 0
+	// disables it. Commit, push, pull and clone are never limited.
+	CommandTimeoutMs int `yaml:"command_timeout_ms"`
 }
 
 // AIConfig holds AI commit settings
 type AIConfig struct {
-	Provider    string  `yaml:"provider"` // openai, anthropic
+	Provider    string  `yaml:"provider"` // openai, anthropic, gemini
 	Model       string  `yaml:"model"`
 	APIKey      string  `yaml:"api_key"`
 	MaxDiffSize int     `yaml:"max_diff_size"`
 	Temperature float64 `yaml:"temperature"`
+
+	// MaxTokens caps the length of the AI response
+	MaxTokens int `yaml:"max_tokens"`
+
+	// MaxBodyLines caps the number of body lines/bullets, 0 means no limit
+	MaxBodyLines int `yaml:"max_body_lines"`
+
+	// Style is the commit message style: conventional, gitmoji or plain
+	Style string `yaml:"style"`
+
+	// Language is the human language commit messages are written in
+	Language string `yaml:"language"`
+
+	// MaxRetries is how often a 429 or 5xx response is retried with backoff
+	MaxRetries int `yaml:"max_retries"`
+
+	// Candidates is how many messages to generate and pick from
+	Candidates int `yaml:"candidates"`
+
+	// BaseURL points the openai provider at a compatible endpoint such as
+	// Azure OpenAI, OpenRouter or a local proxy
+	BaseURL string `yaml:"base_url"`
+
+	// ProviderTemperatures overrides Temperature per provider, e.g. anthropic: 0.3
+	ProviderTemperatures map[string]float64 `yaml:"provider_temperatures,omitempty"`
+
+	// APIKeyCmd prints the key on stdout, e.g. "pass show openai"
+	APIKeyCmd string `yaml:"api_key_cmd,omitempty"`
+
+	// APIKeyFile holds the key, e.g. ~/.secrets/openai
+	APIKeyFile string `yaml:"api_key_file,omitempty"`
+
+	// externalKey is the key found outside the config file, which Save leaves out
+	externalKey string
+}
+
+// languageHint allows names like "Japanese", "Brazilian Portuguese" or "pt-BR"
+var languageHint = regexp.MustCompile(`^\p{L}[\p{L} ()-]{0,39}$`)
+
+// LanguageFor returns the configured commit message language, defaulting to English
+func (c AIConfig) LanguageFor() (string, error) {
+	lang := strings.TrimSpace(c.Language)
+	if lang == "" {
+		return "English", nil
+	}
+	if !languageHint.MatchString(lang) {
+		return "", fmt.Errorf("ai.language %q should be a language name like \"Spanish\"", lang)
+	}
+	return lang, nil
+}
+
+// TemperatureFor returns the temperature to use for a provider, falling back
+// to the global value, and checks it against the provider's accepted range
+func (c AIConfig) TemperatureFor(provider string) (float64, error) {
+	temp := c.Temperature
+	if t, ok := c.ProviderTemperatures[provider]; ok {
+		temp = t
+	}
+
+	// Anthropic only accepts 0-1, OpenAI-style APIs accept 0-2
+	maxTemp := 2.0
+	if provider == "anthropic" {
+		maxTemp = 1.0
+	}
+	if temp < 0 || temp > maxTemp {
+		return 0, fmt.Errorf("temperature %.2f for %s must be between 0 and %.0f", temp, provider, maxTemp)
+	}
+	return temp, nil
 }
 
 // UIConfig holds UI preferences
@@ -36,6 +139,21 @@ type UIConfig struct {
 	Theme       string `yaml:"theme"` // charm, dracula, catppuccin
 	ShowIcons   bool   `yaml:"show_icons"`
 	AnimationMs int    `yaml:"animation_ms"`
+	Browser     string `yaml:"browser"`      // empty uses the platform default
+	ProjectsDir string `yaml:"projects_dir"` // root scanned by the project picker
+
+	// DoubleConfirmAI asks once more, with the diff stat, before an AI message is committed
+	DoubleConfirmAI bool `yaml:"double_confirm_ai"`
+
+	// ConfirmQuit asks before quitting while there are uncommitted changes
+	ConfirmQuit bool `yaml:"confirm_quit"`
+
+	// Mouse lets the menu be clicked and views be scrolled with the wheel; it
+	// is opt-in since it runs gitty full screen and shift is needed to select text
+	Mouse bool `yaml:"mouse"`
+
+	// Keybindings overrides menu shortcuts by action name, e.g. push: "P"
+	Keybindings map[string]string `yaml:"keybindings"`
 }
 
 // GitHubConfig holds GitHub publishing settings
@@ -50,7 +168,16 @@ func DefaultConfig() *Config {
 		Git: GitConfig{
 			UserName:  "",
 			UserEmail: "",
-			Editor:    "vim",
+			Editor:    "",
+
+			DefaultBranch: "main",
+
+			AutoTrackOnCreate: false,
+			SignCommits:       false,
+			TicketVerb:        "Closes",
+			SafetyStash:       true,
+			MaxSubjectLen:     72,
+			CommandTimeoutMs:  0,
 		},
 		AI: AIConfig{
 			Provider:    "openai",
@@ -58,11 +185,25 @@ func DefaultConfig() *Config {
 			APIKey:      "",
 			MaxDiffSize: 4000,
 			Temperature: 0.7,
+			MaxTokens:   1024,
+
+			MaxBodyLines: 0,
+			Style:        "conventional",
+			Language:     "English",
+			BaseURL:      "",
+			Candidates:   1,
+			MaxRetries:   2,
 		},
 		UI: UIConfig{
 			Theme:       "charm",
 			ShowIcons:   true,
 			AnimationMs: 100,
+			Browser:     "",
+			ProjectsDir: "",
+
+			DoubleConfirmAI: false,
+			ConfirmQuit:     false,
+			Mouse:           false,
 		},
 		GitHub: GitHubConfig{
 			DefaultVisibility: "public",
@@ -71,8 +212,15 @@ func DefaultConfig() *Config {
 	}
 }
 
-// ConfigPath returns the path to the config file
+// ConfigPath returns the path to the config file: $GITTY_CONFIG when set,
+// else gitty/config.yaml under $XDG_CONFIG_HOME or ~/.config
 func ConfigPath() string {
+	if path := os.Getenv("GITTY_CONFIG"); path != "" {
+		return path
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "gitty", "config.yaml")
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return ".gitty.yaml"
@@ -80,59 +228,395 @@ func ConfigPath() string {
 	return filepath.Join(home, ".config", "gitty", "config.yaml")
 }
 
+// Exists reports whether a config file has already been written
+func Exists() bool {
+	_, err := os.Stat(ConfigPath())
+	return err == nil
+}
+
 // Load loads the configuration from file or returns default
 func Load() (*Config, error) {
 	cfg := DefaultConfig()
 
 	path := ConfigPath()
 	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		// Defaults apply until first-run setup writes the file
+	case err != nil:
+		return DefaultConfig(), fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	default:
+		// yaml errors name the line, e.g. "yaml: line 3: did not find expected key"
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return DefaultConfig(), fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+		}
+	}
+
+	// The key is looked up before the repo's overrides are applied, so
+	// ai.api_key_cmd and ai.api_key_file only ever come from the global file
+	keyErr := ResolveAPIKey(cfg)
+
+	// Per-repo overrides win over the global config; a bad one is only a warning
+	localErr := mergeLocal(cfg)
+
+	if keyErr != nil {
+		return cfg, errors.Join(localErr, keyErr)
+	}
+	return cfg, localErr
+}
+
+// ResolveAPIKey looks up a key kept outside the config file for the current
+// provider, unless one is written in the file. Call it again after changing
+// ai.provider.
+func ResolveAPIKey(cfg *Config) error {
+	if cfg.AI.APIKey != "" && cfg.AI.APIKey != cfg.AI.externalKey {
+		return nil
+	}
+	key, err := externalAPIKey(cfg.AI)
+	cfg.AI.APIKey = key
+	cfg.AI.externalKey = key
 	if err != nil {
-		if os.IsNotExist(err) {
-			// Try to create default config
-			_ = Save(cfg)
-			return cfg, nil
+		return fmt.Errorf("%w: %v", ErrAPIKey, err)
+	}
+	return nil
+}
+
+// APIKeyEnv names the environment variable holding a provider's key
+func APIKeyEnv(provider string) string {
+	switch provider {
+	case "anthropic":
+		return "ANTHROPIC_API_KEY"
+	case "gemini":
+		return "GEMINI_API_KEY"
+	}
+	return "OPENAI_API_KEY"
+}
+
+// apiKeyCmdTimeout bounds ai.api_key_cmd, e.g. a password manager waiting on a prompt
+const apiKeyCmdTimeout = 30 * time.Second
+
+// externalAPIKey looks up the key in the provider's environment variable,
+// ai.api_key_file and ai.api_key_cmd, in that order
+func externalAPIKey(ai AIConfig) (string, error) {
+	if key := os.Getenv(APIKeyEnv(ai.Provider)); key != "" {
+		return key, nil
+	}
+
+	if ai.APIKeyFile != "" {
+		path := ai.APIKeyFile
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, rest)
+			}
 		}
-		return cfg, nil
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("ai.api_key_file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
 	}
 
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return DefaultConfig(), err
+	if ai.APIKeyCmd != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), apiKeyCmdTimeout)
+		defer cancel()
+		output, err := exec.CommandContext(ctx, "sh", "-c", ai.APIKeyCmd).Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+				return "", fmt.Errorf("ai.api_key_cmd: %s: %w", strings.TrimSpace(string(exitErr.Stderr)), err)
+			}
+			return "", fmt.Errorf("ai.api_key_cmd: %w", err)
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	return "", nil
+}
+
+// ErrInvalidConfig marks a global config file that exists but can't be read
+// or parsed; it is left untouched so nothing the user wrote is lost
+var ErrInvalidConfig = errors.New("invalid config")
+
+// ErrAPIKey marks a failing ai.api_key_file or ai.api_key_cmd; the rest of
+// the config is loaded
+var ErrAPIKey = errors.New("cannot read API key")
+
+// ErrLocalConfig marks a problem with a per-repo .gitty.yaml, which leaves
+// the global config intact
+var ErrLocalConfig = errors.New("invalid local config")
+
+// LocalConfigPath returns the .gitty.yaml at the root of the current repo, if any
+func LocalConfigPath() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
 	}
 
-	// Override API key from environment if not set in config
-	if cfg.AI.APIKey == "" {
-		cfg.AI.APIKey = os.Getenv("OPENAI_API_KEY")
+	// Walk up to the directory holding .git
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			path := filepath.Join(dir, ".gitty.yaml")
+			if _, err := os.Stat(path); err == nil {
+				return path, true
+			}
+			return "", false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
 	}
+}
 
-	return cfg, nil
+// localFields are the settings a repo's .gitty.yaml may change. The file comes
+// with whatever repo was cloned, so anything that runs a command, reads a file
+// or decides where data and keys are sent can only be set globally.
+var localFields = map[string]bool{
+	"ai.style":            true,
+	"ai.language":         true,
+	"ai.max_body_lines":   true,
+	"git.ticket_verb":     true,
+	"git.max_subject_len": true,
+	"ui.theme":            true,
+	"ui.show_icons":       true,
+	"ui.animation_ms":     true,
 }
 
-// Save saves the configuration to file
+// filterLocal splits a repo config into the fields it may set and the names
+// of those it may not
+func filterLocal(layer map[string]any) (map[string]any, []string) {
+	allowed := map[string]any{}
+	var ignored []string
+	for section, value := range layer {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			ignored = append(ignored, section)
+			continue
+		}
+		kept := map[string]any{}
+		for field, v := range fields {
+			if name := section + "." + field; !localFields[name] {
+				ignored = append(ignored, name)
+				continue
+			}
+			kept[field] = v
+		}
+		if len(kept) > 0 {
+			allowed[section] = kept
+		}
+	}
+	sort.Strings(ignored)
+	return allowed, ignored
+}
+
+// mergeLocal overlays the repo's .gitty.yaml, keeping global values for absent
+// fields and ignoring those outside localFields
+func mergeLocal(cfg *Config) error {
+	path, ok := LocalConfigPath()
+	if !ok {
+		return nil
+	}
+
+	layer, err := readLayer(path)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v, ignoring it", ErrLocalConfig, path, err)
+	}
+	allowed, ignored := filterLocal(layer)
+	data, err := yaml.Marshal(allowed)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v, ignoring it", ErrLocalConfig, path, err)
+	}
+	merged := *cfg
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return fmt.Errorf("%w: %s: %v, ignoring it", ErrLocalConfig, path, err)
+	}
+	*cfg = merged
+
+	if len(ignored) > 0 {
+		return fmt.Errorf("%w: %s: ignoring %s, which only the global config can set",
+			ErrLocalConfig, path, strings.Join(ignored, ", "))
+	}
+	return nil
+}
+
+// Save writes the configuration back to the layers it came from: fields the
+// repo's .gitty.yaml sets stay there, everything else goes to the global file
 func Save(cfg *Config) error {
-	path := ConfigPath()
+	// A key picked up from the environment, a secrets file or a command stays
+	// there rather than in the file
+	out := *cfg
+	if out.AI.APIKey != "" && out.AI.APIKey == out.AI.externalKey {
+		out.AI.APIKey = ""
+	}
+
+	localPath, ok := LocalConfigPath()
+	if !ok {
+		return writeConfig(ConfigPath(), &out)
+	}
 
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	local, err := readLayer(localPath)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrLocalConfig, localPath, err)
+	}
+	// A missing or broken global file just means no global values to keep
+	onDisk, _ := readLayer(ConfigPath())
+
+	data, err := yaml.Marshal(&out)
+	if err != nil {
 		return err
 	}
+	var merged map[string]any
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return err
+	}
+	before, _ := yaml.Marshal(local)
+	splitLocal(merged, local, onDisk, "")
+	after, _ := yaml.Marshal(local)
 
-	data, err := yaml.Marshal(cfg)
+	// Round-trip through Config to keep the global file in field order
+	global := DefaultConfig()
+	data, err = yaml.Marshal(merged)
 	if err != nil {
 		return err
 	}
+	if err := yaml.Unmarshal(data, global); err != nil {
+		return err
+	}
 
-	return os.WriteFile(path, data, 0644)
+	if err := writeConfig(ConfigPath(), global); err != nil {
+		return err
+	}
+	// Leave the repo's file, and its comments, alone unless a value changed
+	if bytes.Equal(before, after) {
+		return nil
+	}
+	return writeConfig(localPath, local)
+}
+
+// readLayer reads a config file as plain YAML, so only the fields it sets show up
+func readLayer(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	layer := map[string]any{}
+	if err := yaml.Unmarshal(data, &layer); err != nil {
+		return nil, err
+	}
+	return layer, nil
 }
 
-// EnsureConfig ensures the config file exists with defaults
-func EnsureConfig() (*Config, error) {
-	cfg, err := Load()
+// splitLocal moves the current value of every field the local layer may set
+// from merged into local, leaving merged with what the global file had
+func splitLocal(merged, local, global map[string]any, prefix string) {
+	for key, value := range local {
+		sub, isMap := value.(map[string]any)
+		mergedSub, mergedIsMap := merged[key].(map[string]any)
+		if isMap && mergedIsMap {
+			globalSub, _ := global[key].(map[string]any)
+			splitLocal(mergedSub, sub, globalSub, prefix+key+".")
+			continue
+		}
+		// Ignored on load, so the value in use is the global one
+		if !localFields[prefix+key] {
+			continue
+		}
+
+		if current, ok := merged[key]; ok {
+			local[key] = current
+		}
+		if old, ok := global[key]; ok {
+			merged[key] = old
+		} else {
+			delete(merged, key)
+		}
+	}
+}
+
+// writeConfig marshals v to path, creating the directory if needed
+func writeConfig(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(v)
 	if err != nil {
-		cfg = DefaultConfig()
-		if saveErr := Save(cfg); saveErr != nil {
-			return cfg, saveErr
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// RedactKey masks an API key for display, keeping just enough to tell keys
+// apart, e.g. "sk-...abcd"
+func RedactKey(key string) string {
+	if key == "" {
+		return ""
+	}
+	if len(key) <= 12 {
+		return "****"
+	}
+	return key[:3] + "..." + key[len(key)-4:]
+}
+
+// Validate reports settings that are out of range or misspelled, which
+// would otherwise only surface when the feature using them runs
+func Validate(cfg *Config) []error {
+	var errs []error
+	oneOf := func(field, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		errs = append(errs, fmt.Errorf("%s %q must be one of %s", field, value, strings.Join(allowed, ", ")))
+	}
+	nonNegative := func(field string, value int) {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %d", field, value))
+		}
+	}
+
+	oneOf("ai.provider", cfg.AI.Provider, "openai", "anthropic", "gemini")
+	if _, err := cfg.AI.TemperatureFor(cfg.AI.Provider); err != nil {
+		errs = append(errs, fmt.Errorf("ai.temperature: %w", err))
+	}
+	for provider := range cfg.AI.ProviderTemperatures {
+		if provider == cfg.AI.Provider {
+			continue
+		}
+		if _, err := cfg.AI.TemperatureFor(provider); err != nil {
+			errs = append(errs, fmt.Errorf("ai.provider_temperatures: %w", err))
 		}
 	}
-	return cfg, nil
+	if cfg.AI.Style != "" {
+		oneOf("ai.style", cfg.AI.Style, "conventional", "gitmoji", "plain")
+	}
+	if _, err := cfg.AI.LanguageFor(); err != nil {
+		errs = append(errs, err)
+	}
+	nonNegative("ai.max_diff_size", cfg.AI.MaxDiffSize)
+	nonNegative("ai.max_tokens", cfg.AI.MaxTokens)
+	nonNegative("ai.max_body_lines", cfg.AI.MaxBodyLines)
+	nonNegative("ai.max_retries", cfg.AI.MaxRetries)
+	nonNegative("ai.candidates", cfg.AI.Candidates)
+
+	if cfg.Git.TicketVerb != "" {
+		oneOf("git.ticket_verb", cfg.Git.TicketVerb, "Closes", "Fixes", "Refs")
+	}
+	nonNegative("git.max_subject_len", cfg.Git.MaxSubjectLen)
+	nonNegative("git.command_timeout_ms", cfg.Git.CommandTimeoutMs)
+	if strings.ContainsAny(cfg.Git.DefaultBranch, " ~^:?*[\\") {
+		errs = append(errs, fmt.Errorf("git.default_branch %q is not a valid branch name", cfg.Git.DefaultBranch))
+	}
+
+	oneOf("github.default_visibility", cfg.GitHub.DefaultVisibility, "public", "private")
+
+	if cfg.UI.Theme != "" {
+		oneOf("ui.theme", cfg.UI.Theme, "charm", "dracula", "catppuccin")
+	}
+	nonNegative("ui.animation_ms", cfg.UI.AnimationMs)
+
+	return errs
 }
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// inRepo runs the test from a fresh repo whose .gitty.yaml holds local, with
+// an empty global config
+func inRepo(t *testing.T, local string) string {
+	t.Helper()
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitty.yaml"), []byte(local), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITTY_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("OPENAI_API_KEY", "")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+func TestLoadIgnoresUnsafeLocalFields(t *testing.T) {
+	dir := inRepo(t, `ai:
+  api_key_cmd: "touch pwned"
+  api_key_file: "~/.ssh/id_ed25519"
+  base_url: "http://evil.example"
+  style: "gitmoji"
+git:
+  editor: "sh -c 'touch pwned'"
+ui:
+  theme: "dracula"
+`)
+
+	cfg, err := Load()
+	if !errors.Is(err, ErrLocalConfig) {
+		t.Errorf("err = %v, want a local config warning", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "repo", "pwned")); statErr == nil {
+		t.Error("ai.api_key_cmd from .gitty.yaml was run")
+	}
+	if cfg.AI.APIKeyCmd != "" || cfg.AI.APIKeyFile != "" || cfg.AI.APIKey != "" {
+		t.Errorf("key settings taken from .gitty.yaml: cmd %q, file %q, key %q", cfg.AI.APIKeyCmd, cfg.AI.APIKeyFile, cfg.AI.APIKey)
+	}
+	if cfg.AI.BaseURL != "" || cfg.Git.Editor != "" {
+		t.Errorf("base_url %q, editor %q taken from .gitty.yaml", cfg.AI.BaseURL, cfg.Git.Editor)
+	}
+	if cfg.AI.Style != "gitmoji" || cfg.UI.Theme != "dracula" {
+		t.Errorf("style %q, theme %q: allowed local fields not applied", cfg.AI.Style, cfg.UI.Theme)
+	}
+}
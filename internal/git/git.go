@@ -1,127 +1,506 @@
 package git
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+// dryRun makes destructive commands report what they would run instead
+var dryRun bool
+
+// ErrDryRun is returned by destructive commands while dry-run mode is on
+var ErrDryRun = errors.New("dry run")
+
+// SetDryRun turns dry-run mode on or off
+func SetDryRun(on bool) {
+	dryRun = on
+}
+
+// DryRun reports whether dry-run mode is on
+func DryRun() bool {
+	return dryRun
+}
+
+// dryRunError describes the git command that was skipped
+func dryRunError(args ...string) error {
+	return fmt.Errorf("%w: would run git %s", ErrDryRun, strings.Join(args, " "))
+}
+
+// commandTimeout bounds each local git and gh call; zero means no limit.
+// Commits, pushes, pulls and clones wait on hooks, prompts or the network and
+// are only stopped by CancelRunning.
+var commandTimeout time.Duration
+
+// ErrTimeout is returned when a command runs longer than the configured timeout
+var ErrTimeout = errors.New("timed out")
+
+// ErrCancelled is returned when CancelRunning stops a command
+var ErrCancelled = errors.New("cancelled")
+
+// running is cancelled by CancelRunning to stop every in-flight command
+var running struct {
+	sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func init() {
+	running.ctx, running.cancel = context.WithCancel(context.Background())
+}
+
+// SetCommandTimeout sets how long a single git or gh call may run
+func SetCommandTimeout(d time.Duration) {
+	commandTimeout = d
+}
+
+// CancelRunning stops all commands currently in flight
+func CancelRunning() {
+	running.Lock()
+	defer running.Unlock()
+	running.cancel()
+	running.ctx, running.cancel = context.WithCancel(context.Background())
+}
+
+// cancelableCmd is an exec.Cmd stopped by CancelRunning or the command timeout, whose
+// Run, Output, CombinedOutput and Wait report those as ErrCancelled or ErrTimeout
+type cancelableCmd struct {
+	*exec.Cmd
+	ctx     context.Context
+	release context.CancelFunc
+	timeout time.Duration
+}
+
+// command prepares name with args under the configured timeout
+func command(name string, args ...string) *cancelableCmd {
+	return commandWithTimeout(commandTimeout, name, args...)
+}
+
+// commandWithTimeout prepares a command with its own timeout, zero for none
+func commandWithTimeout(timeout time.Duration, name string, args ...string) *cancelableCmd {
+	running.Lock()
+	parent := running.ctx
+	running.Unlock()
+
+	ctx, release := context.WithCancel(parent)
+	if timeout > 0 {
+		ctx, release = context.WithTimeout(parent, timeout)
+	}
+	return &cancelableCmd{Cmd: exec.CommandContext(ctx, name, args...), ctx: ctx, release: release, timeout: timeout}
+}
+
+func (c *cancelableCmd) Run() error {
+	defer c.release()
+	return c.interrupted(c.Cmd.Run())
+}
+
+func (c *cancelableCmd) Output() ([]byte, error) {
+	defer c.release()
+	output, err := c.Cmd.Output()
+	return output, c.interrupted(err)
+}
+
+func (c *cancelableCmd) CombinedOutput() ([]byte, error) {
+	defer c.release()
+	output, err := c.Cmd.CombinedOutput()
+	return output, c.interrupted(err)
+}
+
+// Start releases the context right away when the command can't start, since
+// Wait, which would otherwise release it, is never called then
+func (c *cancelableCmd) Start() error {
+	if err := c.Cmd.Start(); err != nil {
+		c.release()
+		return c.interrupted(err)
+	}
+	return nil
+}
+
+func (c *cancelableCmd) Wait() error {
+	defer c.release()
+	return c.interrupted(c.Cmd.Wait())
+}
+
+// interrupted explains a failure caused by cancellation or the timeout
+func (c *cancelableCmd) interrupted(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch c.ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Errorf("%s %w after %s", c.Args[0], ErrTimeout, c.timeout)
+	case context.Canceled:
+		return fmt.Errorf("%s %w", c.Args[0], ErrCancelled)
+	}
+	return err
+}
+
+// outputError wraps err with the command's output, when there is any
+func outputError(output []byte, err error) error {
+	if errors.Is(err, ErrTimeout) || errors.Is(err, ErrCancelled) {
+		return err
+	}
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return err
+	}
+	return fmt.Errorf("%s: %w", text, err)
+}
+
 // Status represents the current git repository status
 type Status struct {
-	IsRepo         bool
-	Branch         string
-	HasStaged      bool
-	HasUnstaged    bool
-	HasUntracked   bool
-	Ahead          int
-	Behind         int
-	StagedFiles    []string
-	ModifiedFiles  []string
-	UntrackedFiles []string
-	RemoteURL      string
-}
-
-// GetStatus returns the current git status
+	IsRepo          bool     `json:"is_repo"`
+	Branch          string   `json:"branch"`
+	Detached        bool     `json:"detached"`
+	HasStaged       bool     `json:"has_staged"`
+	HasUnstaged     bool     `json:"has_unstaged"`
+	HasUntracked    bool     `json:"has_untracked"`
+	Ahead           int      `json:"ahead"`
+	Behind          int      `json:"behind"`
+	StagedFiles     []string `json:"staged_files"`
+	ModifiedFiles   []string `json:"modified_files"`
+	UntrackedFiles  []string `json:"untracked_files"`
+	HasConflicts    bool     `json:"has_conflicts"`
+	ConflictedFiles []string `json:"conflicted_files"`
+	RemoteURL       string   `json:"remote_url"`
+	HasRemotes      bool     `json:"has_remotes"`
+	Operation       string   `json:"operation"` // see InProgressOperation
+}
+
+// statusTTL is how long GetStatus reuses its last result
+const statusTTL = 500 * time.Millisecond
+
+// statusCache holds the last status per working directory, which main keeps
+// at the repository root
+var statusCache struct {
+	sync.Mutex
+	dir    string
+	at     time.Time
+	status *Status
+	gen    int // bumped on invalidation so an in-flight read isn't cached
+}
+
+// InvalidateStatusCache drops the cached status; every helper that changes
+// the repository calls it
+func InvalidateStatusCache() {
+	statusCache.Lock()
+	statusCache.status = nil
+	statusCache.gen++
+	statusCache.Unlock()
+}
+
+// GetStatus returns the current git status, reusing a result from the last
+// statusTTL unless the repository was changed through this package since
 func GetStatus() (*Status, error) {
+	dir, _ := os.Getwd()
+
+	statusCache.Lock()
+	if statusCache.status != nil && statusCache.dir == dir && time.Since(statusCache.at) < statusTTL {
+		status := *statusCache.status
+		statusCache.Unlock()
+		return &status, nil
+	}
+	gen := statusCache.gen
+	statusCache.Unlock()
+
+	status, err := readStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	statusCache.Lock()
+	if statusCache.gen == gen {
+		cached := *status
+		statusCache.dir, statusCache.at, statusCache.status = dir, time.Now(), &cached
+	}
+	statusCache.Unlock()
+	return status, nil
+}
+
+// readStatus runs git to build the status. Branch, ahead/behind and the file
+// lists all come from one `git status --porcelain=v2 --branch` call.
+func readStatus() (*Status, error) {
 	status := &Status{}
 
-	// Check if we're in a git repo
-	if !IsRepo() {
+	cmd := command("git", "status", "--porcelain=v2", "--branch", "-z")
+	output, err := cmd.Output()
+	if err != nil {
+		// Usually not a repository; anything else leaves the details empty
+		status.IsRepo = IsRepo()
 		return status, nil
 	}
 	status.IsRepo = true
 
-	// Get current branch
-	branch, err := GetBranch()
-	if err == nil {
-		status.Branch = branch
+	parsePorcelainV2(string(output), status)
+	if status.Detached {
+		if short, err := ShortHead(); err == nil {
+			status.Branch += " at " + short
+		}
 	}
 
 	// Get remote URL
 	url, _ := GetRemoteURL()
 	status.RemoteURL = url
 
-	// Get porcelain status
-	cmd := exec.Command("git", "status", "--porcelain")
-	output, err := cmd.Output()
-	if err != nil {
-		return status, nil
+	// Push and pull can use any remote, not only origin
+	status.HasRemotes = url != ""
+	if !status.HasRemotes {
+		remotes, _ := ListRemotes()
+		status.HasRemotes = len(remotes) > 0
 	}
 
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if len(line) < 3 {
+	status.Operation = InProgressOperation()
+
+	return status, nil
+}
+
+// parsePorcelainV2 fills status from `git status --porcelain=v2 --branch -z`
+// output, where entries are NUL-separated and paths are never quoted
+func parsePorcelainV2(output string, status *Status) {
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
 			continue
 		}
-		x := line[0]
-		y := line[1]
-		file := strings.TrimSpace(line[3:])
 
-		// Staged changes (index)
-		if x != ' ' && x != '?' {
-			status.HasStaged = true
-			status.StagedFiles = append(status.StagedFiles, file)
-		}
+		switch entry[0] {
+		case '#':
+			// # branch.oid, branch.head, branch.upstream and branch.ab headers
+			fields := strings.Fields(entry)
+			if len(fields) < 3 {
+				continue
+			}
+			switch fields[1] {
+			case "branch.head":
+				status.Branch = fields[2]
+			case "branch.ab":
+				if len(fields) == 4 {
+					fmt.Sscanf(fields[2], "+%d", &status.Ahead)
+					fmt.Sscanf(fields[3], "-%d", &status.Behind)
+				}
+			}
 
-		// Unstaged changes (worktree)
-		if y != ' ' && y != '?' {
-			status.HasUnstaged = true
-			status.ModifiedFiles = append(status.ModifiedFiles, file)
-		}
+		case '1', '2', 'u':
+			// 1 XY sub mH mI mW hH hI path
+			// 2 XY sub mH mI mW hH hI Xscore path, then the original path as its own entry
+			// u XY sub m1 m2 m3 mW h1 h2 h3 path
+			parts := map[byte]int{'1': 9, '2': 10, 'u': 11}[entry[0]]
+			fields := strings.SplitN(entry, " ", parts)
+			if len(fields) < parts {
+				continue
+			}
+			if entry[0] == '2' {
+				i++
+			}
+			x, y, file := fields[1][0], fields[1][1], fields[parts-1]
+
+			// Unmerged paths (UU, AA, DU, ...) are neither staged nor plain edits
+			if entry[0] == 'u' {
+				status.HasConflicts = true
+				status.ConflictedFiles = append(status.ConflictedFiles, file)
+				continue
+			}
+
+			// Staged changes (index)
+			if x != '.' {
+				status.HasStaged = true
+				status.StagedFiles = append(status.StagedFiles, file)
+			}
+
+			// Unstaged changes (worktree)
+			if y != '.' {
+				status.HasUnstaged = true
+				status.ModifiedFiles = append(status.ModifiedFiles, file)
+			}
 
-		// Untracked files
-		if x == '?' && y == '?' {
+		case '?':
+			// Untracked files
 			status.HasUntracked = true
-			status.UntrackedFiles = append(status.UntrackedFiles, file)
+			status.UntrackedFiles = append(status.UntrackedFiles, entry[2:])
 		}
 	}
 
-	// Get ahead/behind counts
-	aheadBehind, _ := exec.Command("git", "rev-list", "--left-right", "--count", "HEAD...@{upstream}").Output()
-	if len(aheadBehind) > 0 {
-		parts := strings.Fields(string(aheadBehind))
-		if len(parts) == 2 {
-			fmt.Sscanf(parts[0], "%d", &status.Ahead)
-			fmt.Sscanf(parts[1], "%d", &status.Behind)
-		}
+	// readStatus adds the commit, abbreviated the way git status does
+	if status.Branch == "(detached)" {
+		status.Detached = true
+		status.Branch = "HEAD detached"
 	}
+}
 
-	return status, nil
+// ConflictedFiles returns the paths left unmerged by a merge, rebase or cherry-pick
+func ConflictedFiles() ([]string, error) {
+	status, err := GetStatus()
+	if err != nil {
+		return nil, err
+	}
+	return status.ConflictedFiles, nil
 }
 
 // IsRepo checks if current directory is a git repository
 func IsRepo() bool {
-	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	cmd := command("git", "rev-parse", "--is-inside-work-tree")
 	err := cmd.Run()
 	return err == nil
 }
 
+// CloneDir returns the directory git clone would create for url
+func CloneDir(url string) string {
+	name := strings.TrimRight(url, "/")
+	name = strings.TrimSuffix(name, ".git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// Clone clones url into dir, or git's default when dir is empty, sending
+// progress lines on progress, which is closed when the clone finishes
+func Clone(url, dir string, progress chan<- string) error {
+	defer close(progress)
+
+	args := []string{"clone", "--progress", url}
+	if dir != "" {
+		args = append(args, dir)
+	}
+	target := dir
+	if target == "" {
+		target = CloneDir(url)
+	}
+	_, statErr := os.Stat(target)
+	existed := statErr == nil
+	// A large clone can legitimately outlast the timeout, so only CancelRunning stops it
+	cmd := commandWithTimeout(0, "git", args...)
+	// Fail instead of waiting on a credential prompt the TUI cannot show
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	lines := scanProgress(stderr, progress)
+
+	if err := cmd.Wait(); err != nil {
+		if errors.Is(err, ErrCancelled) {
+			// A killed clone can't clean up after itself
+			if !existed {
+				os.RemoveAll(target)
+			}
+			return err
+		}
+		output := strings.Join(lines, "\n")
+		if isAuthFailure(output) {
+			return fmt.Errorf("authentication failed for %s, check your SSH key or credential helper, or use the HTTPS URL for a public repo: %w", url, err)
+		}
+		return fmt.Errorf("%s: %w", lastLine(lines), err)
+	}
+	return nil
+}
+
+// scanProgress reads command output line by line, sending each non-empty
+// line on progress, and returns them all once r is exhausted
+func scanProgress(r io.Reader, progress chan<- string) []string {
+	// Progress counters are redrawn with \r, so treat it as a line break
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+		// Drop updates nobody is reading yet rather than stall the command
+		select {
+		case progress <- line:
+		default:
+		}
+	}
+	return lines
+}
+
+// isAuthFailure reports whether git output looks like rejected or missing credentials
+func isAuthFailure(output string) bool {
+	lower := strings.ToLower(output)
+	for _, hint := range []string{"authentication failed", "permission denied (publickey)", "could not read username", "could not read password", "terminal prompts disabled"} {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastLine returns the final entry of lines, which for git is usually the fatal error
+func lastLine(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return lines[len(lines)-1]
+}
+
 // Init initializes a new git repository
-func Init() error {
-	cmd := exec.Command("git", "init")
-	return cmd.Run()
+func Init(branch string) error {
+	defer InvalidateStatusCache()
+	args := []string{"init"}
+	if branch != "" {
+		args = append(args, "--initial-branch="+branch)
+	}
+	output, err := command("git", args...).CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
 }
 
-// GetBranch returns the current branch name
+// GetBranch returns the current branch name, or an empty string on a detached HEAD
 func GetBranch() (string, error) {
-	cmd := exec.Command("git", "branch", "--show-current")
+	cmd := command("git", "branch", "--show-current")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
 	}
-	branch := strings.TrimSpace(string(output))
-	if branch == "" {
-		return "main", nil
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ShortHead returns the abbreviated hash of HEAD
+func ShortHead() (string, error) {
+	cmd := command("git", "rev-parse", "--short", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
 	}
-	return branch, nil
+	return strings.TrimSpace(string(output)), nil
 }
 
 // Add stages files for commit
 func Add(files ...string) error {
+	defer InvalidateStatusCache()
 	args := append([]string{"add"}, files...)
-	cmd := exec.Command("git", args...)
+	cmd := command("git", args...)
 	return cmd.Run()
 }
 
@@ -130,86 +509,528 @@ func AddAll() error {
 	return Add(".")
 }
 
+// CommitOptions adjust how Commit runs
+type CommitOptions struct {
+	Sign     bool // GPG-sign the commit (-S)
+	NoVerify bool // skip pre-commit and commit-msg hooks (--no-verify)
+}
+
 // Commit creates a commit with the given message
-func Commit(message string) error {
-	cmd := exec.Command("git", "commit", "-m", message)
-	return cmd.Run()
+func Commit(message string, opts CommitOptions) error {
+	defer InvalidateStatusCache()
+	args := []string{"commit", "-m", message}
+	if opts.Sign {
+		args = append(args, "-S")
+	}
+	if opts.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	// Hooks and a gpg pinentry can take as long as they need; only
+	// CancelRunning stops a commit
+	cmd := commandWithTimeout(0, "git", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		// Hook output and identity errors end up here
+		if opts.Sign {
+			return signingError(string(output), err)
+		}
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// signingError explains a failed signed commit or tag, which git otherwise
+// reports only as a terse gpg failure
+func signingError(output string, err error) error {
+	output = strings.TrimSpace(output)
+	lower := strings.ToLower(output)
+	for _, hint := range []string{"gpg failed to sign", "secret key not available", "no secret key", "cannot run gpg", "signing failed"} {
+		if strings.Contains(lower, hint) {
+			return fmt.Errorf("signing failed, check that a GPG key is configured (git config user.signingkey) and gpg can use it: %s: %w", output, err)
+		}
+	}
+	return fmt.Errorf("%s: %w", output, err)
+}
+
+var (
+	trailerLine  = regexp.MustCompile(`^([\w-]+: .+|(Closes|Fixes|Resolves|Refs) \S+)$`)
+	jiraTicket   = regexp.MustCompile(`[A-Z][A-Z0-9]+-\d+`)
+	issueNumber  = regexp.MustCompile(`(?:^|/)(\d+)(?:[-_]|$)`)
+	bareIssueRef = regexp.MustCompile(`^\d+$`)
+)
+
+// AppendTrailer adds a footer line such as "Closes #123" to a commit message,
+// keeping it in the same block as any existing trailers
+func AppendTrailer(message, trailer string) string {
+	message = strings.TrimRight(message, "\n ")
+	paragraphs := strings.Split(message, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+
+	isTrailerBlock := len(paragraphs) > 1
+	for _, line := range strings.Split(last, "\n") {
+		if line == trailer {
+			return message
+		}
+		if !trailerLine.MatchString(line) {
+			isTrailerBlock = false
+		}
+	}
+
+	if isTrailerBlock {
+		return message + "\n" + trailer
+	}
+	return message + "\n\n" + trailer
+}
+
+// TicketFromBranch guesses an issue reference from a branch name, e.g.
+// "feature/PROJ-456-login" gives "PROJ-456" and "fix/123-crash" gives "#123"
+func TicketFromBranch(branch string) string {
+	if ticket := jiraTicket.FindString(branch); ticket != "" {
+		return ticket
+	}
+	if match := issueNumber.FindStringSubmatch(branch); match != nil {
+		return "#" + match[1]
+	}
+	return ""
+}
+
+// NormalizeTicket turns a bare issue number into a "#123" reference
+func NormalizeTicket(ticket string) string {
+	ticket = strings.TrimSpace(ticket)
+	if bareIssueRef.MatchString(ticket) {
+		return "#" + ticket
+	}
+	return ticket
+}
+
+// CleanupMessage tidies a message written in an editor the way git commit
+// does: comment lines and trailing whitespace go, and runs of blank lines
+// collapse into one
+func CleanupMessage(message string) string {
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
 }
 
 // Push pushes to remote
 func Push() error {
-	cmd := exec.Command("git", "push")
+	defer InvalidateStatusCache()
+	cmd := commandWithTimeout(0, "git", "push")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("%s: %w", string(output), err)
+		if strings.Contains(string(output), "has no upstream branch") {
+			return ErrNoUpstream
+		}
+		return pushError(string(output), err)
+	}
+	return nil
+}
+
+// ErrNoUpstream is returned by Push when the current branch tracks nothing yet
+var ErrNoUpstream = errors.New("the current branch has no upstream branch")
+
+// ErrPushRejected is returned when the remote has commits the push would overwrite
+var ErrPushRejected = errors.New("push rejected: the remote has commits you don't have locally")
+
+// pushError wraps a failed push, recognising non-fast-forward rejections
+func pushError(output string, err error) error {
+	if strings.Contains(output, "non-fast-forward") || strings.Contains(output, "fetch first") {
+		return fmt.Errorf("%w: %s", ErrPushRejected, strings.TrimSpace(output))
+	}
+	return fmt.Errorf("%s: %w", output, err)
+}
+
+// PushForceWithLease force pushes, refusing if the remote moved since the last fetch
+func PushForceWithLease() error {
+	defer InvalidateStatusCache()
+	if dryRun {
+		return dryRunError("push", "--force-with-lease")
+	}
+	cmd := commandWithTimeout(0, "git", "push", "--force-with-lease")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return pushError(string(output), err)
+	}
+	return nil
+}
+
+// PushTo pushes branch to the given remote
+func PushTo(remote, branch string) error {
+	defer InvalidateStatusCache()
+	cmd := commandWithTimeout(0, "git", "push", remote, branch)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return pushError(string(output), err)
 	}
 	return nil
 }
 
 // PushWithUpstream pushes and sets upstream
 func PushWithUpstream(remote, branch string) error {
-	cmd := exec.Command("git", "push", "-u", remote, branch)
+	defer InvalidateStatusCache()
+	cmd := commandWithTimeout(0, "git", "push", "-u", remote, branch)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("%s: %w", string(output), err)
+		return outputError(output, err)
 	}
 	return nil
 }
 
+// ErrPullConflict is returned when a pull stops on conflicting changes
+var ErrPullConflict = errors.New("pull stopped on conflicts: resolve them and commit, or abort")
+
 // Pull pulls from remote
 func Pull() error {
-	cmd := exec.Command("git", "pull")
+	defer InvalidateStatusCache()
+	cmd := commandWithTimeout(0, "git", "pull")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "CONFLICT") {
+			return ErrPullConflict
+		}
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// SafetyStash stashes all local changes, untracked files included, before a
+// destructive command and returns the stash commit, or "" when there was
+// nothing to save. Unlike stash@{0}, the hash keeps naming this stash after
+// later pushes.
+func SafetyStash(message string) (string, error) {
+	defer InvalidateStatusCache()
+	before, _ := command("git", "rev-parse", "-q", "--verify", "refs/stash").Output()
+	output, err := command("git", "stash", "push", "--include-untracked", "-m", message).CombinedOutput()
+	if err != nil {
+		return "", outputError(output, err)
+	}
+	after, _ := command("git", "rev-parse", "-q", "--verify", "refs/stash").Output()
+	if bytes.Equal(before, after) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(after)), nil
+}
+
+// Reset performs a hard reset
+func Reset() error {
+	defer InvalidateStatusCache()
+	if dryRun {
+		return dryRunError("reset", "--hard")
+	}
+	cmd := command("git", "reset", "--hard")
+	return cmd.Run()
+}
+
+// UnpushedCount returns how many commits on HEAD are not on any remote branch
+func UnpushedCount() (int, error) {
+	cmd := command("git", "rev-list", "--count", "HEAD", "--not", "--remotes")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	_, err = fmt.Sscanf(strings.TrimSpace(string(output)), "%d", &count)
+	return count, err
+}
+
+// CommitDates returns the author date (YYYY-MM-DD) of every commit on HEAD since the given day
+func CommitDates(since time.Time) ([]string, error) {
+	cmd := command("git", "log", "--since="+since.Format("2006-01-02"), "--format=%ad", "--date=short")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, outputError(output, err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// CommitInfo is a commit as listed by git log
+type CommitInfo struct {
+	Hash    string
+	Subject string
+	Body    string
+}
+
+// HeadCommit returns the commit HEAD points at
+func HeadCommit() (CommitInfo, error) {
+	commits, err := logCommits("-1", "HEAD")
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if len(commits) == 0 {
+		return CommitInfo{}, fmt.Errorf("no commits yet")
+	}
+	return commits[0], nil
+}
+
+// CommitsSince returns the commits on HEAD that are not on base, newest first
+func CommitsSince(base string) ([]CommitInfo, error) {
+	return logCommits(base + "..HEAD")
+}
+
+// CommitsToPick returns the commits on branch that HEAD does not have yet,
+// newest first, skipping ones already applied under another hash
+func CommitsToPick(branch string) ([]CommitInfo, error) {
+	return logCommits("--cherry-pick", "--right-only", "--max-count=200", "HEAD..."+branch)
+}
+
+// logCommits runs git log with args and parses the commits it lists
+func logCommits(args ...string) ([]CommitInfo, error) {
+	// Fields are separated by US and records by RS so bodies can hold newlines
+	cmd := command("git", append([]string{"log", "--format=%H%x1f%s%x1f%b%x1e"}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, outputError(output, err)
+	}
+
+	var commits []CommitInfo
+	for _, record := range strings.Split(string(output), "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		commits = append(commits, CommitInfo{
+			Hash:    fields[0],
+			Subject: fields[1],
+			Body:    strings.TrimSpace(fields[2]),
+		})
+	}
+	return commits, nil
+}
+
+// RefExists reports whether a branch, tag or other ref resolves to a commit
+func RefExists(ref string) bool {
+	cmd := command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return cmd.Run() == nil
+}
+
+// Rollback resets to previous commit
+func Rollback() error {
+	defer InvalidateStatusCache()
+	if dryRun {
+		return dryRunError("reset", "--hard", "HEAD^")
+	}
+	cmd := command("git", "reset", "--hard", "HEAD^")
+	return cmd.Run()
+}
+
+// UntrackedToClean returns the files `git clean -f` would remove (dry run)
+func UntrackedToClean() ([]string, error) {
+	cmd := command("git", "clean", "-n")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("%s: %w", string(output), err)
+		return nil, outputError(output, err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		file := strings.TrimPrefix(line, "Would remove ")
+		if file == "" || file == line {
+			continue
+		}
+		// Names with quotes, backslashes or control characters come C-quoted
+		if unquoted, err := strconv.Unquote(file); err == nil {
+			file = unquoted
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// CleanUntracked removes the given untracked files, so nothing created after
+// they were listed goes with them
+func CleanUntracked(files []string) error {
+	defer InvalidateStatusCache()
+	if len(files) == 0 {
+		return nil
+	}
+	args := append([]string{"--literal-pathspecs", "clean", "-f", "--"}, files...)
+	cmd := command("git", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
 	}
 	return nil
 }
 
-// Reset performs a hard reset
-func Reset() error {
-	cmd := exec.Command("git", "reset", "--hard")
-	return cmd.Run()
+// HasStagedChanges checks if there are any staged changes
+func HasStagedChanges() bool {
+	cmd := command("git", "diff", "--cached", "--quiet")
+	err := cmd.Run()
+	// Exit code 1 means differences were found (changes exist)
+	// Exit code 0 means no differences (clean)
+	return err != nil
+}
+
+// GetDiff returns the staged diff
+func GetDiff() (string, error) {
+	cmd := command("git", "diff", "--cached")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// GetDiffStat returns the diffstat of the staged changes
+func GetDiffStat() (string, error) {
+	cmd := command("git", "diff", "--cached", "--stat")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+// GetShortStat returns the one-line summary of the staged changes, e.g.
+// "3 files changed, 42 insertions(+), 8 deletions(-)"
+func GetShortStat() (string, error) {
+	output, err := command("git", "diff", "--cached", "--shortstat").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetDiffSummary returns the stat and summary of the staged changes, which
+// also describe mode changes and submodule updates that have no textual diff
+func GetDiffSummary() (string, error) {
+	output, err := command("git", "diff", "--cached", "--stat", "--summary").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+// GetFullDiff returns both staged and unstaged diff
+func GetFullDiff() (string, error) {
+	cmd := command("git", "diff", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// GetUnstagedDiff returns the changes in the working tree that are not staged
+func GetUnstagedDiff() (string, error) {
+	cmd := command("git", "diff")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// Hunk is one @@ section of a file's diff
+type Hunk struct {
+	File   string   // path as shown in the diff header
+	Header []string // diff --git, index, --- and +++ lines of the file
+	Lines  []string // the @@ line followed by the hunk body
 }
 
-// Rollback resets to previous commit
-func Rollback() error {
-	cmd := exec.Command("git", "reset", "--hard", "HEAD^")
-	return cmd.Run()
+// ParseHunks splits a diff into hunks. Only plain modifications are kept:
+// new, deleted, renamed and binary files are left for whole-file staging.
+func ParseHunks(diff string) []Hunk {
+	var hunks []Hunk
+	var header []string
+	var file string
+	var current *Hunk
+	skip := false
+
+	flush := func() {
+		if current != nil && !skip {
+			hunks = append(hunks, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			header = []string{line}
+			file = ""
+			skip = false
+		case current == nil && !strings.HasPrefix(line, "@@"):
+			header = append(header, line)
+			switch {
+			case strings.HasPrefix(line, "+++ b/"):
+				file = strings.TrimPrefix(line, "+++ b/")
+			case strings.HasPrefix(line, "new file mode"), strings.HasPrefix(line, "deleted file mode"),
+				strings.HasPrefix(line, "rename from"), strings.HasPrefix(line, "Binary files"):
+				skip = true
+			}
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			current = &Hunk{File: file, Header: header, Lines: []string{line}}
+		default:
+			current.Lines = append(current.Lines, line)
+		}
+	}
+	flush()
+	return hunks
 }
 
-// HasStagedChanges checks if there are any staged changes
-func HasStagedChanges() bool {
-	cmd := exec.Command("git", "diff", "--cached", "--quiet")
-	err := cmd.Run()
-	// Exit code 1 means differences were found (changes exist)
-	// Exit code 0 means no differences (clean)
-	return err != nil
+// HunkPatch builds a patch git apply accepts from the given hunks
+func HunkPatch(hunks []Hunk) string {
+	var b strings.Builder
+	lastFile := ""
+	for _, h := range hunks {
+		if h.File != lastFile {
+			b.WriteString(strings.Join(h.Header, "\n") + "\n")
+			lastFile = h.File
+		}
+		b.WriteString(strings.Join(h.Lines, "\n") + "\n")
+	}
+	return b.String()
 }
 
-// GetDiff returns the staged diff
-func GetDiff() (string, error) {
-	cmd := exec.Command("git", "diff", "--cached")
-	output, err := cmd.Output()
+// ApplyCached stages a patch without touching the working tree
+func ApplyCached(patch string) error {
+	defer InvalidateStatusCache()
+	cmd := command("git", "apply", "--cached", "-")
+	cmd.Stdin = strings.NewReader(patch)
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", err
+		return outputError(output, err)
 	}
-	return string(output), nil
+	return nil
 }
 
-// GetFullDiff returns both staged and unstaged diff
-func GetFullDiff() (string, error) {
-	cmd := exec.Command("git", "diff", "HEAD")
-	output, err := cmd.Output()
+// DiffBranch returns the diff between the working tree and another branch
+func DiffBranch(branch string, statOnly bool) (string, error) {
+	args := []string{"diff"}
+	if statOnly {
+		args = append(args, "--stat")
+	}
+	args = append(args, branch, "--")
+
+	cmd := command("git", args...)
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", err
+		return "", outputError(output, err)
 	}
 	return string(output), nil
 }
 
 // GetRemoteURL returns the origin remote URL
 func GetRemoteURL() (string, error) {
-	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd := command("git", "remote", "get-url", "origin")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -219,10 +1040,16 @@ func GetRemoteURL() (string, error) {
 
 // SetConfig sets a git config value
 func SetConfig(key, value string) error {
-	cmd := exec.Command("git", "config", key, value)
+	cmd := command("git", "config", key, value)
 	return cmd.Run()
 }
 
+// HasIdentity reports whether git knows who to record as the author
+func HasIdentity() bool {
+	output, err := command("git", "config", "user.email").Output()
+	return err == nil && strings.TrimSpace(string(output)) != ""
+}
+
 // SetUser sets the user name and email
 func SetUser(name, email string) error {
 	if err := SetConfig("user.name", name); err != nil {
@@ -233,7 +1060,7 @@ func SetUser(name, email string) error {
 
 // GetBranches returns all branches
 func GetBranches() ([]string, error) {
-	cmd := exec.Command("git", "branch", "-a")
+	cmd := command("git", "branch", "-a")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -249,20 +1076,175 @@ func GetBranches() ([]string, error) {
 	return branches, nil
 }
 
+// BranchAheadBehind returns how many commits branch is ahead of and behind
+// its upstream. Branches without an upstream report 0, 0 and no error.
+func BranchAheadBehind(branch string) (int, int, error) {
+	if command("git", "rev-parse", "--verify", "--quiet", branch+"@{upstream}").Run() != nil {
+		return 0, 0, nil
+	}
+
+	cmd := command("git", "rev-list", "--left-right", "--count", branch+"..."+branch+"@{upstream}")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, 0, outputError(output, err)
+	}
+
+	var ahead, behind int
+	if _, err := fmt.Sscanf(string(output), "%d %d", &ahead, &behind); err != nil {
+		return 0, 0, err
+	}
+	return ahead, behind, nil
+}
+
 // CreateBranch creates and checks out a new branch
 func CreateBranch(name string) error {
-	cmd := exec.Command("git", "checkout", "-b", name)
-	return cmd.Run()
+	defer InvalidateStatusCache()
+	cmd := command("git", "checkout", "-b", name)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// Upstream returns the upstream of the current branch, e.g. "origin/main"
+func Upstream() (string, error) {
+	cmd := command("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
 }
 
 // Checkout switches to a branch
 func Checkout(branch string) error {
-	cmd := exec.Command("git", "checkout", branch)
+	defer InvalidateStatusCache()
+	cmd := command("git", "checkout", branch)
 	return cmd.Run()
 }
 
+// ErrMergeConflict is returned when a merge stops on conflicting changes
+var ErrMergeConflict = errors.New("merge conflict: resolve the conflicts and commit, or abort the merge")
+
+// Merge merges branch into the current branch, forcing a merge commit when noFF is set
+func Merge(branch string, noFF bool) error {
+	defer InvalidateStatusCache()
+	args := []string{"merge"}
+	if noFF {
+		args = append(args, "--no-ff")
+	}
+	args = append(args, "--no-edit", branch)
+	output, err := command("git", args...).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "CONFLICT") {
+			return ErrMergeConflict
+		}
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// MergeAbort abandons an in-progress merge
+func MergeAbort() error {
+	defer InvalidateStatusCache()
+	output, err := command("git", "merge", "--abort").CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// ErrCherryPickConflict is returned when a cherry-pick stops on conflicting changes
+var ErrCherryPickConflict = errors.New("cherry-pick conflict: resolve the conflicts and commit, or abort the cherry-pick")
+
+// CherryPick applies the commit hash onto the current branch
+func CherryPick(hash string) error {
+	defer InvalidateStatusCache()
+	output, err := command("git", "cherry-pick", hash).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "CONFLICT") {
+			return ErrCherryPickConflict
+		}
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// CherryPickAbort abandons an in-progress cherry-pick
+func CherryPickAbort() error {
+	defer InvalidateStatusCache()
+	output, err := command("git", "cherry-pick", "--abort").CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// InProgressOperation names the operation waiting on conflict resolution:
+// "merge", "rebase", "cherry-pick" or "" when there is none
+func InProgressOperation() string {
+	checks := []struct{ op, path string }{
+		{"merge", "MERGE_HEAD"},
+		{"rebase", "rebase-merge"},
+		{"rebase", "rebase-apply"},
+		{"cherry-pick", "CHERRY_PICK_HEAD"},
+	}
+	// One call resolves every path, since the status runs this each refresh
+	args := []string{"rev-parse"}
+	for _, check := range checks {
+		args = append(args, "--git-path", check.path)
+	}
+	output, err := command("git", args...).Output()
+	if err != nil {
+		return ""
+	}
+	paths := strings.Split(strings.TrimSpace(string(output)), "\n")
+	for i, check := range checks {
+		if i >= len(paths) {
+			break
+		}
+		if _, err := os.Stat(paths[i]); err == nil {
+			return check.op
+		}
+	}
+	return ""
+}
+
+// AbortOperation abandons the in-progress merge, rebase or cherry-pick
+func AbortOperation() error {
+	switch op := InProgressOperation(); op {
+	case "merge":
+		return MergeAbort()
+	case "cherry-pick":
+		return CherryPickAbort()
+	case "rebase":
+		defer InvalidateStatusCache()
+		output, err := command("git", "rebase", "--abort").CombinedOutput()
+		if err != nil {
+			return outputError(output, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("no merge, rebase or cherry-pick in progress")
+	}
+}
+
+// MarkResolved stages file to record its conflict as resolved
+func MarkResolved(file string) error {
+	defer InvalidateStatusCache()
+	output, err := command("git", "add", "--", file).CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
+}
+
 // GetRepoName returns the repository name from the current directory
 func GetRepoName() string {
+	if root, err := RepoRoot(); err == nil {
+		return filepath.Base(root)
+	}
 	cwd, err := os.Getwd()
 	if err != nil {
 		return "repo"
@@ -270,85 +1252,425 @@ func GetRepoName() string {
 	return filepath.Base(cwd)
 }
 
+// RepoRoot returns the top-level directory of the current repository
+func RepoRoot() (string, error) {
+	cmd := command("git", "rev-parse", "--show-toplevel")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", outputError(output, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // HasRemote checks if a remote exists
 func HasRemote(name string) bool {
-	cmd := exec.Command("git", "remote", "get-url", name)
+	cmd := command("git", "remote", "get-url", name)
 	return cmd.Run() == nil
 }
 
 // AddRemote adds a new remote
 func AddRemote(name, url string) error {
-	cmd := exec.Command("git", "remote", "add", name, url)
-	return cmd.Run()
+	defer InvalidateStatusCache()
+	output, err := command("git", "remote", "add", name, url).CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// ListRemotes returns each remote's fetch URL by name
+func ListRemotes() (map[string]string, error) {
+	cmd := command("git", "remote", "-v")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, outputError(output, err)
+	}
+
+	remotes := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		// origin	git@github.com:owner/repo.git (fetch)
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[2] == "(fetch)" {
+			remotes[fields[0]] = fields[1]
+		}
+	}
+	return remotes, nil
+}
+
+// SetRemoteURL changes the URL of an existing remote
+func SetRemoteURL(name, url string) error {
+	defer InvalidateStatusCache()
+	output, err := command("git", "remote", "set-url", name, url).CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// RemoveRemote deletes a remote and its remote-tracking branches
+func RemoveRemote(name string) error {
+	defer InvalidateStatusCache()
+	output, err := command("git", "remote", "remove", name).CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
+}
+
+// LatestTag returns the most recent tag reachable from HEAD
+func LatestTag() (string, error) {
+	cmd := command("git", "describe", "--tags", "--abbrev=0")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", outputError(output, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ListTags returns all tags, highest version first
+func ListTags() ([]string, error) {
+	cmd := command("git", "tag", "--list", "--sort=-v:refname")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, outputError(output, err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// TagExists reports whether a tag with the given name exists locally
+func TagExists(name string) bool {
+	return command("git", "rev-parse", "-q", "--verify", "refs/tags/"+name).Run() == nil
+}
+
+// DeleteTag deletes a tag locally and, when remote is set, on origin as well
+func DeleteTag(name string, remote bool) error {
+	output, err := command("git", "tag", "--delete", name).CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	if !remote {
+		return nil
+	}
+
+	output, err = commandWithTimeout(0, "git", "push", "--delete", "origin", name).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("deleted locally, but not on origin: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
 }
 
 // Tag creates a new tag
 func Tag(name string) error {
-	cmd := exec.Command("git", "tag", name)
-	return cmd.Run()
+	output, err := command("git", "tag", name).CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
 }
 
 // TagAnnotated creates a new annotated tag with a message
-func TagAnnotated(name, message string) error {
-	var cmd *exec.Cmd
-	if message == "" {
-		cmd = exec.Command("git", "tag", name)
-	} else {
-		cmd = exec.Command("git", "tag", "-a", name, "-m", message)
+func TagAnnotated(name, message string, sign bool) error {
+	var cmd *cancelableCmd
+	switch {
+	case sign:
+		// Signed tags are annotated and need a message
+		if message == "" {
+			message = name
+		}
+		cmd = command("git", "tag", "-s", name, "-m", message)
+	case message == "":
+		cmd = command("git", "tag", name)
+	default:
+		cmd = command("git", "tag", "-a", name, "-m", message)
 	}
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("%s: %w", string(output), err)
+		if sign {
+			return signingError(string(output), err)
+		}
+		return outputError(output, err)
 	}
 	return nil
 }
 
 // PushTags pushes all tags to remote
 func PushTags() error {
-	cmd := exec.Command("git", "push", "--tags")
-	return cmd.Run()
+	output, err := commandWithTimeout(0, "git", "push", "--tags").CombinedOutput()
+	if err != nil {
+		return outputError(output, err)
+	}
+	return nil
 }
 
-// GetGitHubURL converts git URL to GitHub web URL
-func GetGitHubURL() (string, error) {
+// webHosts are the hosting services whose remotes can be opened in a browser
+var webHosts = []string{"github.com", "gitlab.com", "bitbucket.org"}
+
+// GetWebURL converts the origin URL of a GitHub, GitLab or Bitbucket repo to its web URL
+func GetWebURL() (string, error) {
 	url, err := GetRemoteURL()
 	if err != nil {
 		return "", err
 	}
+	return webURL(url)
+}
 
-	if !strings.Contains(url, "github.com") {
-		return "", fmt.Errorf("not a GitHub repository")
+// webURL converts an SSH or HTTPS remote URL to an HTTPS web URL
+func webURL(url string) (string, error) {
+	remote, err := ParseRemote(url)
+	if err != nil {
+		return "", err
+	}
+
+	supported := false
+	for _, host := range webHosts {
+		if remote.Host == host {
+			supported = true
+			break
+		}
 	}
+	if !supported {
+		return "", fmt.Errorf("not a GitHub, GitLab or Bitbucket repository")
+	}
+
+	return remote.HTTPSURL(), nil
+}
+
+// Remote is a remote URL broken into its parts
+type Remote struct {
+	Host  string
+	Owner string // may contain slashes for nested groups
+	Repo  string
+}
+
+// ParseRemote parses SSH (git@host:owner/repo, ssh://...) and HTTPS remote URLs
+func ParseRemote(url string) (Remote, error) {
+	var hostAndPath string
+	switch {
+	case strings.Contains(url, "://"):
+		_, rest, _ := strings.Cut(url, "://")
+		host, path, ok := strings.Cut(rest, "/")
+		if !ok {
+			return Remote{}, fmt.Errorf("cannot parse remote URL %q", url)
+		}
+		// Drop credentials and ports, e.g. ssh://git@host:22/...
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		host, _, _ = strings.Cut(host, ":")
+		hostAndPath = host + "/" + path
+	case strings.Contains(url, ":"):
+		userHost, path, _ := strings.Cut(url, ":")
+		if at := strings.LastIndex(userHost, "@"); at >= 0 {
+			userHost = userHost[at+1:]
+		}
+		hostAndPath = userHost + "/" + path
+	default:
+		return Remote{}, fmt.Errorf("cannot parse remote URL %q", url)
+	}
+
+	hostAndPath = strings.TrimSuffix(strings.TrimSuffix(hostAndPath, "/"), ".git")
+	parts := strings.Split(hostAndPath, "/")
+	if len(parts) < 3 || parts[0] == "" {
+		return Remote{}, fmt.Errorf("cannot parse remote URL %q", url)
+	}
+
+	return Remote{
+		Host:  parts[0],
+		Owner: strings.Join(parts[1:len(parts)-1], "/"),
+		Repo:  parts[len(parts)-1],
+	}, nil
+}
+
+// HTTPSURL returns the web and HTTPS clone URL without the .git suffix
+func (r Remote) HTTPSURL() string {
+	return fmt.Sprintf("https://%s/%s/%s", r.Host, r.Owner, r.Repo)
+}
 
-	// Convert SSH to HTTPS
-	if strings.HasPrefix(url, "git@") {
-		url = strings.TrimPrefix(url, "git@")
-		url = strings.Replace(url, ":", "/", 1)
-		url = "https://" + url
+// SSHURL returns the scp-style SSH clone URL
+func (r Remote) SSHURL() string {
+	return fmt.Sprintf("git@%s:%s/%s.git", r.Host, r.Owner, r.Repo)
+}
+
+// GetGitHubURL converts git URL to GitHub web URL
+func GetGitHubURL() (string, error) {
+	url, err := GetWebURL()
+	if err != nil {
+		return "", err
 	}
 
-	// Remove .git suffix
-	url = strings.TrimSuffix(url, ".git")
+	if !strings.Contains(url, "github.com") {
+		return "", fmt.Errorf("not a GitHub repository")
+	}
 
 	return url, nil
 }
 
-// OpenBrowser opens a URL in the default browser
-func OpenBrowser(url string) error {
+// OpenBrowser opens a URL with the given browser command, or the platform's
+// default opener when browser is empty
+func OpenBrowser(url, browser string) error {
 	var cmd *exec.Cmd
 
-	// Try zen-browser first, then xdg-open
-	if _, err := exec.LookPath("zen-browser"); err == nil {
-		cmd = exec.Command("zen-browser", url)
-	} else if _, err := exec.LookPath("zen"); err == nil {
-		cmd = exec.Command("zen", url)
+	if fields := strings.Fields(browser); len(fields) > 0 {
+		cmd = exec.Command(fields[0], append(fields[1:], url)...)
 	} else {
-		cmd = exec.Command("xdg-open", url)
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("cmd", "/c", "start", "", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
 	}
 
 	return cmd.Start()
 }
 
+// PullRequest is an open pull request as reported by gh
+type PullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Branch string `json:"headRefName"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+// ListPullRequests returns the open pull requests of the current repo via gh
+func ListPullRequests() ([]PullRequest, error) {
+	if !HasGh() {
+		return nil, fmt.Errorf("gh cli error: gh is not installed")
+	}
+
+	cmd := command("gh", "pr", "list", "--state", "open", "--limit", "50", "--json", "number,title,headRefName,author")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("gh cli error: %s - %w", strings.TrimSpace(string(output)), err)
+	}
+
+	var prs []PullRequest
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	return prs, nil
+}
+
+// CheckoutPullRequest checks out the branch of a pull request via gh
+func CheckoutPullRequest(number int) error {
+	defer InvalidateStatusCache()
+	cmd := command("gh", "pr", "checkout", fmt.Sprint(number))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gh cli error: %s - %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// HasGh reports whether the GitHub CLI is installed
+func HasGh() bool {
+	_, err := exec.LookPath("gh")
+	return err == nil
+}
+
+// CreateGitHubRelease publishes a GitHub release for an already pushed tag via gh
+func CreateGitHubRelease(tag, notes string) error {
+	args := []string{"release", "create", tag, "--title", tag}
+	if notes != "" {
+		args = append(args, "--notes", notes)
+	} else {
+		args = append(args, "--generate-notes")
+	}
+	cmd := command("gh", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gh cli error: %s - %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// CreateGitHubRepo creates a GitHub repository for the current directory
+// with gh, adds it as origin and pushes
+func CreateGitHubRepo(name, visibility, description string) error {
+	defer InvalidateStatusCache()
+	args := []string{"repo", "create", name, "--" + visibility, "--source=.", "--remote=origin", "--push"}
+	if description != "" {
+		args = append(args, "--description="+description)
+	}
+	return RunGhStreaming(args, nil)
+}
+
+// GitHubRepoURL returns the URL to push to for one of the user's GitHub
+// repositories, over SSH when gh is configured for it
+func GitHubRepoURL(name string) (string, error) {
+	output, err := command("gh", "repo", "view", name, "--json", "url,sshUrl").CombinedOutput()
+	if err != nil {
+		return "", ghError(string(output), err)
+	}
+
+	var repo struct {
+		URL    string `json:"url"`
+		SSHURL string `json:"sshUrl"`
+	}
+	if err := json.Unmarshal(output, &repo); err != nil {
+		return "", fmt.Errorf("failed to parse gh output: %w", err)
+	}
+
+	protocol, _ := command("gh", "config", "get", "git_protocol").Output()
+	if strings.TrimSpace(string(protocol)) == "ssh" && repo.SSHURL != "" {
+		return repo.SSHURL, nil
+	}
+	return repo.URL + ".git", nil
+}
+
+// ErrRepoExists is returned when gh repo create finds the name already taken
+var ErrRepoExists = errors.New("a repository with that name already exists on your GitHub account")
+
+// ErrGhAuth is returned when gh has no usable login
+var ErrGhAuth = errors.New("the GitHub CLI is not logged in or its token expired, run gh auth login")
+
+// RunGhStreaming runs gh with args, sending its output on progress line by
+// line and closing progress, when not nil, once gh exits
+func RunGhStreaming(args []string, progress chan<- string) error {
+	defer InvalidateStatusCache()
+	if progress != nil {
+		defer close(progress)
+	}
+
+	// A push as part of repo create can take a while, so no timeout here
+	cmd := commandWithTimeout(0, "gh", args...)
+	r, w := io.Pipe()
+	cmd.Stdout = w
+	cmd.Stderr = w
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("gh cli error: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		w.Close()
+		done <- err
+	}()
+	lines := scanProgress(r, progress)
+
+	if err := <-done; err != nil {
+		return ghError(strings.Join(lines, "\n"), err)
+	}
+	return nil
+}
+
+// ghError picks out the gh failures that have a specific remedy
+func ghError(output string, err error) error {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "name already exists"):
+		return ErrRepoExists
+	case strings.Contains(lower, "gh auth login") || strings.Contains(lower, "authentication") || strings.Contains(lower, "bad credentials"):
+		return ErrGhAuth
+	}
+	return fmt.Errorf("gh cli error: %s - %w", strings.TrimSpace(output), err)
+}
+
 // CheckDeps checks for required and optional dependencies
 func CheckDeps() []string {
 	var missing []string
@@ -360,7 +1682,7 @@ func CheckDeps() []string {
 
 	// Optional
 	if _, err := exec.LookPath("gh"); err != nil {
-		missing = append(missing, "gh (optional, for publish)")
+		missing = append(missing, "gh (optional, for publish, releases and pull requests)")
 	}
 	if _, err := exec.LookPath("lazygit"); err != nil {
 		missing = append(missing, "lazygit (optional)")
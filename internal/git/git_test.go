@@ -0,0 +1,304 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// tempRepo runs the test from a new repository with an identity and no
+// global or system git config
+func tempRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+	} {
+		if output, err := command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %s", strings.Join(args, " "), output)
+		}
+	}
+	InvalidateStatusCache()
+	return dir
+}
+
+// writeFile creates name in the current directory
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParsePorcelainV2(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []string
+		branch    string
+		ahead     int
+		behind    int
+		staged    []string
+		modified  []string
+		untracked []string
+	}{
+		{
+			name: "branch header",
+			entries: []string{
+				"# branch.oid 1234567890abcdef1234567890abcdef12345678",
+				"# branch.head main",
+				"# branch.upstream origin/main",
+				"# branch.ab +3 -2",
+			},
+			branch: "main",
+			ahead:  3,
+			behind: 2,
+		},
+		{
+			name: "detached head",
+			entries: []string{
+				"# branch.oid 1234567890abcdef1234567890abcdef12345678",
+				"# branch.head (detached)",
+			},
+			branch: "HEAD detached",
+		},
+		{
+			name: "ordinary change",
+			entries: []string{
+				"1 M. N... 100644 100644 100644 aaaaaaa bbbbbbb staged.go",
+				"1 .M N... 100644 100644 100644 aaaaaaa aaaaaaa modified.go",
+				"1 MM N... 100644 100644 100644 aaaaaaa bbbbbbb both.go",
+			},
+			staged:   []string{"staged.go", "both.go"},
+			modified: []string{"modified.go", "both.go"},
+		},
+		{
+			name: "rename",
+			entries: []string{
+				"2 R. N... 100644 100644 100644 aaaaaaa aaaaaaa R100 bar.txt",
+				"foo.txt",
+			},
+			staged: []string{"bar.txt"},
+		},
+		{
+			name: "copy edited afterwards",
+			entries: []string{
+				"2 CM N... 100644 100644 100644 aaaaaaa aaaaaaa C75 dir/with space.txt",
+				"original.txt",
+			},
+			staged:   []string{"dir/with space.txt"},
+			modified: []string{"dir/with space.txt"},
+		},
+		{
+			name: "unusual names in ordinary records",
+			entries: []string{
+				"1 M. N... 100644 100644 100644 aaaaaaa bbbbbbb résumé 日本.md",
+				"1 .M N... 100644 100644 100644 aaaaaaa aaaaaaa say \"hi\".txt",
+				"1 A. N... 000000 100644 100644 0000000 bbbbbbb two\nlines.txt",
+			},
+			staged:   []string{"résumé 日本.md", "two\nlines.txt"},
+			modified: []string{"say \"hi\".txt"},
+		},
+		{
+			name: "unusual names in rename records",
+			entries: []string{
+				"2 R. N... 100644 100644 100644 aaaaaaa aaaaaaa R100 naïve.txt",
+				"naive.txt",
+				"2 R. N... 100644 100644 100644 aaaaaaa aaaaaaa R100 \"quoted\".txt",
+				"quoted.txt",
+				"2 RM N... 100644 100644 100644 aaaaaaa aaaaaaa R90 new\nline.txt",
+				"old\nline.txt",
+			},
+			staged:   []string{"naïve.txt", "\"quoted\".txt", "new\nline.txt"},
+			modified: []string{"new\nline.txt"},
+		},
+		{
+			name: "untracked",
+			entries: []string{
+				"? new.txt",
+				"? dir/other file.txt",
+			},
+			untracked: []string{"new.txt", "dir/other file.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &Status{}
+			parsePorcelainV2(strings.Join(tt.entries, "\x00")+"\x00", status)
+
+			if status.Branch != tt.branch {
+				t.Errorf("Branch = %q, want %q", status.Branch, tt.branch)
+			}
+			if status.Ahead != tt.ahead || status.Behind != tt.behind {
+				t.Errorf("Ahead/Behind = %d/%d, want %d/%d", status.Ahead, status.Behind, tt.ahead, tt.behind)
+			}
+			if !reflect.DeepEqual(status.StagedFiles, tt.staged) {
+				t.Errorf("StagedFiles = %q, want %q", status.StagedFiles, tt.staged)
+			}
+			if !reflect.DeepEqual(status.ModifiedFiles, tt.modified) {
+				t.Errorf("ModifiedFiles = %q, want %q", status.ModifiedFiles, tt.modified)
+			}
+			if !reflect.DeepEqual(status.UntrackedFiles, tt.untracked) {
+				t.Errorf("UntrackedFiles = %q, want %q", status.UntrackedFiles, tt.untracked)
+			}
+			if status.HasStaged != (len(tt.staged) > 0) || status.HasUnstaged != (len(tt.modified) > 0) || status.HasUntracked != (len(tt.untracked) > 0) {
+				t.Errorf("Has flags = %v/%v/%v don't match the file lists", status.HasStaged, status.HasUnstaged, status.HasUntracked)
+			}
+		})
+	}
+}
+
+func TestCommitHookFailure(t *testing.T) {
+	dir := tempRepo(t)
+	hook := filepath.Join(dir, ".git", "hooks", "pre-commit")
+	if err := os.MkdirAll(filepath.Dir(hook), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\necho 'lint: trailing whitespace in main.go' >&2\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, "main.go", "package main \n")
+	if err := AddAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Commit("Add main", CommitOptions{})
+	if err == nil {
+		t.Fatal("commit succeeded despite the failing pre-commit hook")
+	}
+	if !strings.Contains(err.Error(), "lint: trailing whitespace in main.go") {
+		t.Errorf("error %q does not include the hook output", err)
+	}
+
+	if err := Commit("Add main", CommitOptions{NoVerify: true}); err != nil {
+		t.Fatalf("commit with NoVerify failed: %v", err)
+	}
+	if head, err := HeadCommit(); err != nil || head.Subject != "Add main" {
+		t.Errorf("HEAD = %+v, %v, want the new commit", head, err)
+	}
+}
+
+func TestStatusFreshAfterCommit(t *testing.T) {
+	tempRepo(t)
+	writeFile(t, "a.txt", "a\n")
+	if err := AddAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !before.HasStaged {
+		t.Fatalf("staged files = %q, want a.txt", before.StagedFiles)
+	}
+
+	// Well within statusTTL, so a stale cache entry would still be served
+	if err := Commit("Add a", CommitOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	after, err := GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.HasStaged || len(after.StagedFiles) > 0 {
+		t.Errorf("status after commit still lists staged files %q", after.StagedFiles)
+	}
+}
+
+func TestStatusUnusualNames(t *testing.T) {
+	tempRepo(t)
+	names := []string{"résumé 日本.md", "say \"hi\".txt", "two\nlines.txt"}
+	for _, name := range names {
+		writeFile(t, name, "x\n")
+	}
+
+	status, err := GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// git lists paths sorted by byte value, as names already is
+	if !reflect.DeepEqual(status.UntrackedFiles, names) {
+		t.Errorf("UntrackedFiles = %q, want %q without git's quoting", status.UntrackedFiles, names)
+	}
+}
+
+func TestStatusDuringConflictedMerge(t *testing.T) {
+	tempRepo(t)
+	run := func(args ...string) {
+		t.Helper()
+		if output, err := command("git", args...).CombinedOutput(); err != nil && args[0] != "merge" {
+			t.Fatalf("git %s: %s", strings.Join(args, " "), output)
+		}
+	}
+	writeFile(t, "a.txt", "base\n")
+	run("add", "a.txt")
+	run("commit", "-qm", "base")
+	run("checkout", "-qb", "other")
+	writeFile(t, "a.txt", "other\n")
+	run("commit", "-qam", "other")
+	run("checkout", "-q", "-")
+	writeFile(t, "a.txt", "main\n")
+	run("commit", "-qam", "main")
+	run("merge", "other")
+	InvalidateStatusCache()
+
+	status, err := GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Operation != "merge" || !status.HasConflicts {
+		t.Errorf("Operation = %q, HasConflicts = %v, want a conflicted merge", status.Operation, status.HasConflicts)
+	}
+	if status.HasRemotes {
+		t.Error("HasRemotes set without any remote")
+	}
+}
+
+func TestSafetyStashReturnsCommit(t *testing.T) {
+	tempRepo(t)
+	writeFile(t, "a.txt", "a\n")
+	if err := AddAll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Commit("Add a", CommitOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if ref, err := SafetyStash("clean"); err != nil || ref != "" {
+		t.Fatalf("SafetyStash on a clean tree = %q, %v, want nothing stashed", ref, err)
+	}
+
+	writeFile(t, "a.txt", "first\n")
+	ref, err := SafetyStash("first")
+	if err != nil || ref == "" {
+		t.Fatalf("SafetyStash = %q, %v", ref, err)
+	}
+
+	// A later stash takes stash@{0}, the returned commit still names the first
+	writeFile(t, "a.txt", "second\n")
+	if _, err := SafetyStash("second"); err != nil {
+		t.Fatal(err)
+	}
+	output, err := command("git", "show", ref+":a.txt").Output()
+	if err != nil || string(output) != "first\n" {
+		t.Errorf("git show %s:a.txt = %q, %v, want the first stash", ref, output, err)
+	}
+}
@@ -0,0 +1,109 @@
+package projects
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cache holds the result of the last scan of a projects directory
+type Cache struct {
+	Root      string    `json:"root"`
+	ScannedAt time.Time `json:"scanned_at"`
+	Repos     []string  `json:"repos"`
+}
+
+// ExpandHome replaces a leading ~ with the user's home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// Scan finds git repositories one or two levels below root
+func Scan(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		if isRepo(dir) {
+			repos = append(repos, dir)
+			continue
+		}
+
+		// Look one level deeper, e.g. ~/code/<org>/<repo>
+		children, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, child := range children {
+			if !child.IsDir() || strings.HasPrefix(child.Name(), ".") {
+				continue
+			}
+			if sub := filepath.Join(dir, child.Name()); isRepo(sub) {
+				repos = append(repos, sub)
+			}
+		}
+	}
+
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// isRepo reports whether dir contains a .git directory or file (worktrees)
+func isRepo(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// CachePath returns the path to the scan cache file
+func CachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "gitty-projects.json")
+	}
+	return filepath.Join(dir, "gitty", "projects.json")
+}
+
+// LoadCache returns the cached scan for root, if there is one
+func LoadCache(root string) (*Cache, bool) {
+	data, err := os.ReadFile(CachePath())
+	if err != nil {
+		return nil, false
+	}
+
+	var cache Cache
+	if err := json.Unmarshal(data, &cache); err != nil || cache.Root != root {
+		return nil, false
+	}
+	return &cache, true
+}
+
+// SaveCache writes the scan result for later launches
+func SaveCache(cache *Cache) error {
+	path := CachePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
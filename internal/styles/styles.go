@@ -33,15 +33,17 @@ var (
 	BorderAccent  = Purple
 )
 
-// Icons for beautiful display
-var Icons = struct {
+// IconSet holds the glyphs used throughout the UI
+type IconSet struct {
 	Git       string
 	Branch    string
 	Commit    string
+	Diff      string
 	Push      string
 	Pull      string
 	Add       string
 	Reset     string
+	Trash     string
 	Publish   string
 	Open      string
 	AI        string
@@ -58,14 +60,19 @@ var Icons = struct {
 	Info      string
 	Lazygit   string
 	Quit      string
-}{
+}
+
+// NerdIcons are Nerd Font glyphs, the default icon set
+var NerdIcons = IconSet{
 	Git:       "",
 	Branch:    "",
 	Commit:    "",
+	Diff:      "",
 	Push:      "",
 	Pull:      "",
 	Add:       "",
 	Reset:     "",
+	Trash:     "",
 	Publish:   "",
 	Open:      "",
 	AI:        "",
@@ -84,84 +91,148 @@ var Icons = struct {
 	Quit:      "",
 }
 
-// Base styles
+// ASCIIIcons are plain-text fallbacks for terminals without a Nerd Font
+var ASCIIIcons = IconSet{
+	Git:       "*",
+	Branch:    "Y",
+	Commit:    "C",
+	Diff:      "~",
+	Push:      "^",
+	Pull:      "v",
+	Add:       "+",
+	Reset:     "R",
+	Trash:     "-",
+	Publish:   "P",
+	Open:      "O",
+	AI:        "AI",
+	Config:    "#",
+	Check:     "ok",
+	Cross:     "x",
+	Arrow:     "->",
+	Dot:       ".",
+	Star:      "*",
+	Lightning: "!",
+	Folder:    "/",
+	File:      "f",
+	Warning:   "!",
+	Info:      "i",
+	Lazygit:   "lg",
+	Quit:      "q",
+}
+
+// Icons is the active icon set
+var Icons = NerdIcons
+
+// IconsFor returns the Nerd Font icons, or the ASCII fallback when showIcons is false
+func IconsFor(showIcons bool) IconSet {
+	if showIcons {
+		return NerdIcons
+	}
+	return ASCIIIcons
+}
+
+// Base styles, built from the palette by buildStyles
 var (
+	TitleStyle            lipgloss.Style
+	TitleBoxStyle         lipgloss.Style
+	BranchBoxStyle        lipgloss.Style
+	BoxStyle              lipgloss.Style
+	AccentBoxStyle        lipgloss.Style
+	ListItemStyle         lipgloss.Style
+	ListItemSelectedStyle lipgloss.Style
+	ListItemDescStyle     lipgloss.Style
+	SuccessStyle          lipgloss.Style
+	ErrorStyle            lipgloss.Style
+	WarningStyle          lipgloss.Style
+	InfoStyle             lipgloss.Style
+	SpinnerStyle          lipgloss.Style
+	HelpStyle             lipgloss.Style
+	HeaderStyle           lipgloss.Style
+	DividerStyle          lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles (re)creates the base styles from the current palette
+func buildStyles() {
 	// Title styles
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Pink).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(Pink).
+		MarginBottom(1)
 
 	// Box styles with borders
 	TitleBoxStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Pink).
-			Padding(0, 0) // Removed border padding
+		Bold(true).
+		Foreground(Pink).
+		Padding(0, 0) // Removed border padding
 
 	BranchBoxStyle = lipgloss.NewStyle().
-			Foreground(Cyan).
-			Bold(true).
-			Padding(0, 0) // Removed border padding
+		Foreground(Cyan).
+		Bold(true).
+		Padding(0, 0) // Removed border padding
 
 	BoxStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(Border).
-			Padding(1, 2)
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(Border).
+		Padding(1, 2)
 
 	AccentBoxStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(Purple).
-			Padding(1, 2)
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(Purple).
+		Padding(1, 2)
 
 	// List item styles
 	ListItemStyle = lipgloss.NewStyle().
-			Foreground(TextPrimary).
-			PaddingLeft(2)
+		Foreground(TextPrimary).
+		PaddingLeft(2)
 
 	ListItemSelectedStyle = lipgloss.NewStyle().
-				Foreground(Pink).
-				Bold(true).
-				PaddingLeft(0)
+		Foreground(Pink).
+		Bold(true).
+		PaddingLeft(0)
 
 	ListItemDescStyle = lipgloss.NewStyle().
-				Foreground(TextMuted).
-				PaddingLeft(4)
+		Foreground(TextMuted).
+		PaddingLeft(4)
 
 	// Status styles
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(Success).
-			Bold(true)
+		Foreground(Success).
+		Bold(true)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(Error).
-			Bold(true)
+		Foreground(Error).
+		Bold(true)
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(Warning).
-			Bold(true)
+		Foreground(Warning).
+		Bold(true)
 
 	InfoStyle = lipgloss.NewStyle().
-			Foreground(Info)
+		Foreground(Info)
 
 	// Spinner style
 	SpinnerStyle = lipgloss.NewStyle().
-			Foreground(Pink)
+		Foreground(Pink)
 
 	// Help style
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(TextMuted).
-			MarginTop(1)
+		Foreground(TextMuted).
+		MarginTop(1)
 
 	// Header style
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Purple).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(Purple).
+		MarginBottom(1)
 
 	// Divider
 	DividerStyle = lipgloss.NewStyle().
-			Foreground(Border)
-)
+		Foreground(Border)
+}
 
 // Render helpers
 func RenderSuccess(msg string) string {
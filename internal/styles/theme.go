@@ -0,0 +1,91 @@
+package styles
+
+import (
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a color palette together with the matching huh form theme
+type Theme struct {
+	Name   string
+	Pink   lipgloss.Color
+	Purple lipgloss.Color
+	Blue   lipgloss.Color
+	Cyan   lipgloss.Color
+	Red    lipgloss.Color
+	Green  lipgloss.Color
+	Yellow lipgloss.Color
+
+	form func() *huh.Theme
+}
+
+// Form returns the huh theme used for forms
+func (t Theme) Form() *huh.Theme {
+	return t.form()
+}
+
+var themes = map[string]Theme{
+	"charm": {
+		Name:   "charm",
+		Pink:   "#FF6B9D",
+		Purple: "#A855F7",
+		Blue:   "#60A5FA",
+		Cyan:   "#22D3EE",
+		Red:    "#F87171",
+		Green:  "#4ADE80",
+		Yellow: "#FBBF24",
+		form:   huh.ThemeCharm,
+	},
+	"dracula": {
+		Name:   "dracula",
+		Pink:   "#FF79C6",
+		Purple: "#BD93F9",
+		Blue:   "#6272A4",
+		Cyan:   "#8BE9FD",
+		Red:    "#FF5555",
+		Green:  "#50FA7B",
+		Yellow: "#F1FA8C",
+		form:   huh.ThemeDracula,
+	},
+	"catppuccin": {
+		Name:   "catppuccin",
+		Pink:   "#F5C2E7",
+		Purple: "#CBA6F7",
+		Blue:   "#89B4FA",
+		Cyan:   "#89DCEB",
+		Red:    "#F38BA8",
+		Green:  "#A6E3A1",
+		Yellow: "#F9E2AF",
+		form:   huh.ThemeCatppuccin,
+	},
+}
+
+// ThemeFor returns the theme with the given name, falling back to charm
+func ThemeFor(name string) Theme {
+	if t, ok := themes[name]; ok {
+		return t
+	}
+	return themes["charm"]
+}
+
+// Apply switches the palette and rebuilds the base styles
+func Apply(t Theme) {
+	Pink = t.Pink
+	Purple = t.Purple
+	Blue = t.Blue
+	Cyan = t.Cyan
+	Red = t.Red
+	Green = t.Green
+	Yellow = t.Yellow
+
+	Primary = Pink
+	Secondary = Purple
+	Accent = Blue
+	Success = Green
+	Warning = Yellow
+	Error = Red
+	Info = Cyan
+	BorderAccent = Purple
+
+	buildStyles()
+}
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// commitItem implements list.Item
+type commitItem struct {
+	commit git.CommitInfo
+}
+
+func (i commitItem) FilterValue() string { return i.commit.Subject }
+
+// commitDelegate renders a commit as its short hash and subject
+type commitDelegate struct{}
+
+func (d commitDelegate) Height() int                             { return 1 }
+func (d commitDelegate) Spacing() int                            { return 0 }
+func (d commitDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d commitDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(commitItem)
+	if !ok {
+		return
+	}
+
+	hash := lipgloss.NewStyle().Foreground(styles.Yellow).Render(shortHash(i.commit.Hash) + " ")
+
+	var line string
+	if index == m.Index() {
+		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
+		line = arrow + hash + lipgloss.NewStyle().Foreground(styles.Pink).Bold(true).Render(i.commit.Subject)
+	} else {
+		line = "     " + hash + lipgloss.NewStyle().Foreground(styles.TextPrimary).Render(i.commit.Subject)
+	}
+
+	fmt.Fprint(w, line)
+}
+
+// shortHash abbreviates a full commit hash for display
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+type cherryPickState int
+
+const (
+	cherryPickStateLoading cherryPickState = iota
+	cherryPickStateList
+	cherryPickStateWorking
+	cherryPickStateConflict
+	cherryPickStateError
+)
+
+// CherryPickModel lists a branch's commits missing from HEAD and applies one
+type CherryPickModel struct {
+	state   cherryPickState
+	spinner spinner.Model
+	list    list.Model
+	branch  string
+	picked  git.CommitInfo
+	err     error
+}
+
+// NewCherryPickModel creates a commit picker for branch, opened from the branch view
+func NewCherryPickModel(branch string, width, height int) *CherryPickModel {
+	s := newSpinner()
+
+	l := list.New(nil, commitDelegate{}, width, max(height-4, 5))
+	l.Title = "Cherry-pick from " + branch
+	l.Styles.Title = styles.TitleStyle
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.DisableQuitKeybindings()
+
+	return &CherryPickModel{
+		state:   cherryPickStateLoading,
+		spinner: s,
+		list:    l,
+		branch:  branch,
+	}
+}
+
+func (m *CherryPickModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadCommits,
+	)
+}
+
+func (m *CherryPickModel) loadCommits() tea.Msg {
+	commits, err := git.CommitsToPick(m.branch)
+	if err != nil {
+		return cherryPickErrorMsg{err}
+	}
+	if len(commits) == 0 {
+		return closeChildMsg{Message: fmt.Sprintf("%s has no commits missing from this branch", m.branch), Type: "info"}
+	}
+	items := make([]list.Item, len(commits))
+	for i, c := range commits {
+		items[i] = commitItem{c}
+	}
+	return cherryPickLoadedMsg{items}
+}
+
+func (m *CherryPickModel) doPick() tea.Msg {
+	if err := git.CherryPick(m.picked.Hash); err != nil {
+		return cherryPickErrorMsg{err}
+	}
+	return closeChildMsg{Message: fmt.Sprintf("Cherry-picked %s %s", shortHash(m.picked.Hash), m.picked.Subject), Type: "success"}
+}
+
+func (m *CherryPickModel) doAbort() tea.Msg {
+	if err := git.CherryPickAbort(); err != nil {
+		return cherryPickErrorMsg{fmt.Errorf("failed to abort cherry-pick: %w", err)}
+	}
+	return closeChildMsg{Message: fmt.Sprintf("Aborted cherry-pick of %s", shortHash(m.picked.Hash)), Type: "info"}
+}
+
+type cherryPickLoadedMsg struct{ items []list.Item }
+type cherryPickErrorMsg struct{ err error }
+
+func (m *CherryPickModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, max(msg.Height-4, 5))
+
+	case tea.KeyMsg:
+		// Let the list handle typing a filter and clearing it with esc
+		if m.list.SettingFilter() || (msg.String() == "esc" && m.list.IsFiltered()) {
+			break
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			switch m.state {
+			case cherryPickStateWorking:
+				return m, nil
+			case cherryPickStateConflict:
+				return m, func() tea.Msg {
+					return closeChildMsg{Message: "Cherry-pick has conflicts, resolve them and commit", Type: "error"}
+				}
+			}
+			return m, func() tea.Msg { return closeChildMsg{} }
+		case "enter":
+			if m.state != cherryPickStateList {
+				break
+			}
+			if item, ok := m.list.SelectedItem().(commitItem); ok {
+				m.picked = item.commit
+				m.state = cherryPickStateWorking
+				return m, tea.Batch(m.spinner.Tick, m.doPick)
+			}
+		case "a":
+			if m.state == cherryPickStateConflict {
+				m.state = cherryPickStateWorking
+				return m, tea.Batch(m.spinner.Tick, m.doAbort)
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case cherryPickLoadedMsg:
+		m.state = cherryPickStateList
+		return m, m.list.SetItems(msg.items)
+
+	case cherryPickErrorMsg:
+		if errors.Is(msg.err, git.ErrCherryPickConflict) {
+			m.state = cherryPickStateConflict
+		} else {
+			m.state = cherryPickStateError
+		}
+		m.err = msg.err
+		return m, nil
+	}
+
+	if m.state == cherryPickStateList {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *CherryPickModel) View() string {
+	if m.state == cherryPickStateList {
+		return m.list.View() + "\n" + styles.HelpStyle.Render("enter: cherry-pick onto current branch • /: filter • esc: back")
+	}
+
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Commit + " Cherry-pick"))
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case cherryPickStateLoading:
+		b.WriteString(m.spinner.View() + fmt.Sprintf(" Loading commits from %s...", m.branch))
+
+	case cherryPickStateWorking:
+		b.WriteString(m.spinner.View() + fmt.Sprintf(" Applying %s...", shortHash(m.picked.Hash)))
+
+	case cherryPickStateConflict:
+		b.WriteString(styles.RenderError(fmt.Sprintf("Cherry-picking %s stopped on conflicts", shortHash(m.picked.Hash))))
+		b.WriteString("\n\n")
+		b.WriteString(styles.RenderInfo("Resolve the conflicted files and commit, or abort to go back to where you were"))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("a: abort cherry-pick • esc: resolve manually"))
+
+	case cherryPickStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
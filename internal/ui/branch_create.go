@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+type createBranchState int
+
+const (
+	createBranchStateForm createBranchState = iota
+	createBranchStateWorking
+	createBranchStateError
+)
+
+// CreateBranchModel creates a branch and optionally pushes it with an upstream
+type CreateBranchModel struct {
+	cfg     *config.Config
+	state   createBranchState
+	spinner spinner.Model
+	form    *huh.Form
+	name    string
+	track   bool
+	err     error
+}
+
+// NewCreateBranchModel creates a new branch creation model, opened from the branch view
+func NewCreateBranchModel(cfg *config.Config) *CreateBranchModel {
+	s := newSpinner()
+
+	return &CreateBranchModel{
+		cfg:     cfg,
+		state:   createBranchStateForm,
+		spinner: s,
+		track:   cfg.Git.AutoTrackOnCreate,
+	}
+}
+
+func (m *CreateBranchModel) Init() tea.Cmd {
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Branch name").
+				Value(&m.name).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("branch name cannot be empty")
+					}
+					return nil
+				}),
+
+			huh.NewConfirm().
+				Title("Push and set upstream?").
+				Description("Tracks origin/<branch> from the start").
+				Value(&m.track),
+		),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	return tea.Batch(
+		m.spinner.Tick,
+		m.form.Init(),
+	)
+}
+
+type createBranchErrorMsg struct{ err error }
+
+func (m *CreateBranchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "esc" {
+			return m, func() tea.Msg { return closeChildMsg{} }
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case createBranchErrorMsg:
+		m.state = createBranchStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	// Update form
+	if m.state == createBranchStateForm && m.form != nil {
+		form, cmd := m.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.form = f
+		}
+
+		if m.form.State == huh.StateCompleted {
+			m.state = createBranchStateWorking
+			return m, m.doCreate
+		}
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *CreateBranchModel) doCreate() tea.Msg {
+	name := strings.TrimSpace(m.name)
+	if err := git.CreateBranch(name); err != nil {
+		return createBranchErrorMsg{fmt.Errorf("failed to create branch: %w", err)}
+	}
+
+	if !m.track {
+		return closeChildMsg{Message: fmt.Sprintf("Created and switched to %s", name), Type: "success"}
+	}
+
+	if !git.HasRemote("origin") {
+		return closeChildMsg{Message: fmt.Sprintf("Created %s (no origin remote to track)", name), Type: "info"}
+	}
+
+	if err := git.PushWithUpstream("origin", name); err != nil {
+		return closeChildMsg{Message: fmt.Sprintf("Created %s but push failed: %v", name, err), Type: "error"}
+	}
+
+	upstream, err := git.Upstream()
+	if err != nil {
+		upstream = "origin/" + name
+	}
+	return closeChildMsg{Message: fmt.Sprintf("Created %s tracking %s", name, upstream), Type: "success"}
+}
+
+func (m *CreateBranchModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Branch + " New Branch"))
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case createBranchStateForm:
+		if m.form != nil {
+			b.WriteString(m.form.View())
+		}
+
+	case createBranchStateWorking:
+		if m.track {
+			b.WriteString(m.spinner.View() + " Creating branch and pushing...")
+		} else {
+			b.WriteString(m.spinner.View() + " Creating branch...")
+		}
+
+	case createBranchStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+type mergeState int
+
+const (
+	mergeStateForm mergeState = iota
+	mergeStateWorking
+	mergeStateConflict
+	mergeStateError
+)
+
+// MergeModel merges a branch into the current one, opened from the branch view
+type MergeModel struct {
+	cfg       *config.Config
+	state     mergeState
+	spinner   spinner.Model
+	form      *huh.Form
+	branch    string
+	current   string
+	confirmed bool
+	noFF      bool
+	err       error
+}
+
+// NewMergeModel creates a merge confirmation for branch
+func NewMergeModel(cfg *config.Config, branch string) *MergeModel {
+	s := newSpinner()
+
+	current, _ := git.GetBranch()
+
+	return &MergeModel{
+		cfg:     cfg,
+		state:   mergeStateForm,
+		spinner: s,
+		branch:  branch,
+		current: current,
+	}
+}
+
+func (m *MergeModel) Init() tea.Cmd {
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Merge %s into %s?", m.branch, m.current)).
+				Affirmative("Merge").
+				Negative("Cancel").
+				Value(&m.confirmed),
+
+			huh.NewConfirm().
+				Title("Always create a merge commit?").
+				Description("git merge --no-ff").
+				Value(&m.noFF),
+		),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	return tea.Batch(
+		m.spinner.Tick,
+		m.form.Init(),
+	)
+}
+
+type mergeErrorMsg struct{ err error }
+
+func (m *MergeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			if m.state == mergeStateWorking {
+				return m, nil
+			}
+			if m.state == mergeStateConflict {
+				return m, func() tea.Msg {
+					return closeChildMsg{Message: "Merge has conflicts, resolve them and commit", Type: "error"}
+				}
+			}
+			return m, func() tea.Msg { return closeChildMsg{} }
+		case "a":
+			if m.state == mergeStateConflict {
+				m.state = mergeStateWorking
+				return m, m.doAbort
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case mergeErrorMsg:
+		if errors.Is(msg.err, git.ErrMergeConflict) {
+			m.state = mergeStateConflict
+		} else {
+			m.state = mergeStateError
+		}
+		m.err = msg.err
+		return m, nil
+	}
+
+	// Update form
+	if m.state == mergeStateForm && m.form != nil {
+		form, cmd := m.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.form = f
+		}
+
+		if m.form.State == huh.StateCompleted {
+			if !m.confirmed {
+				return m, func() tea.Msg { return closeChildMsg{} }
+			}
+			m.state = mergeStateWorking
+			return m, m.doMerge
+		}
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *MergeModel) doMerge() tea.Msg {
+	if err := git.Merge(m.branch, m.noFF); err != nil {
+		return mergeErrorMsg{err}
+	}
+	return closeChildMsg{Message: fmt.Sprintf("Merged %s into %s", m.branch, m.current), Type: "success"}
+}
+
+func (m *MergeModel) doAbort() tea.Msg {
+	if err := git.MergeAbort(); err != nil {
+		return mergeErrorMsg{fmt.Errorf("failed to abort merge: %w", err)}
+	}
+	return closeChildMsg{Message: fmt.Sprintf("Aborted merge of %s", m.branch), Type: "info"}
+}
+
+func (m *MergeModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Branch + " Merge"))
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case mergeStateForm:
+		if m.form != nil {
+			b.WriteString(m.form.View())
+		}
+
+	case mergeStateWorking:
+		b.WriteString(m.spinner.View() + fmt.Sprintf(" Merging %s...", m.branch))
+
+	case mergeStateConflict:
+		b.WriteString(styles.RenderError(fmt.Sprintf("Merging %s stopped on conflicts", m.branch)))
+		b.WriteString("\n\n")
+		b.WriteString(styles.RenderInfo("Resolve the conflicted files and commit, or abort to go back to where you were"))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("a: abort merge • esc: resolve manually"))
+
+	case mergeStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// branchItem implements list.Item
+type branchItem struct {
+	name    string
+	current bool
+	ahead   int // commits not yet on the upstream
+	behind  int // upstream commits not yet pulled
+}
+
+func (i branchItem) FilterValue() string { return i.name }
+
+// branchDelegate renders branches in the same style as the main menu
+type branchDelegate struct{}
+
+func (d branchDelegate) Height() int                             { return 1 }
+func (d branchDelegate) Spacing() int                            { return 0 }
+func (d branchDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d branchDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(branchItem)
+	if !ok {
+		return
+	}
+
+	marker := "  "
+	if i.current {
+		marker = lipgloss.NewStyle().Foreground(styles.Green).Render("* ")
+	}
+
+	var badges string
+	if i.ahead > 0 {
+		badges += lipgloss.NewStyle().Foreground(styles.Blue).Render(fmt.Sprintf(" ↑%d", i.ahead))
+	}
+	if i.behind > 0 {
+		badges += lipgloss.NewStyle().Foreground(styles.Yellow).Render(fmt.Sprintf(" ↓%d", i.behind))
+	}
+
+	var line string
+	if index == m.Index() {
+		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
+		name := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true).Render(i.name)
+		line = arrow + marker + name + badges
+	} else {
+		name := lipgloss.NewStyle().Foreground(styles.TextPrimary).Render(i.name)
+		line = "     " + marker + name + badges
+	}
+
+	fmt.Fprint(w, line)
+}
+
+type branchesState int
+
+const (
+	branchesStateLoading branchesState = iota
+	branchesStateList
+	branchesStateError
+)
+
+// BranchesModel lists branches and opens actions on the selected one
+type BranchesModel struct {
+	cfg     *config.Config
+	state   branchesState
+	spinner spinner.Model
+	list    list.Model
+	err     error
+	message string
+	msgType string
+	width   int
+	height  int
+
+	// Nested view opened from the list, e.g. a diff
+	child tea.Model
+}
+
+// NewBranchesModel creates a new branch view
+func NewBranchesModel(cfg *config.Config, width, height int) *BranchesModel {
+	s := newSpinner()
+
+	l := list.New(nil, branchDelegate{}, width, max(height-4, 5))
+	l.Title = "Branches"
+	l.Styles.Title = styles.TitleStyle
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.DisableQuitKeybindings()
+
+	return &BranchesModel{
+		cfg:     cfg,
+		state:   branchesStateLoading,
+		spinner: s,
+		list:    l,
+		width:   width,
+		height:  height,
+	}
+}
+
+func (m *BranchesModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadBranches,
+	)
+}
+
+func (m *BranchesModel) loadBranches() tea.Msg {
+	branches, err := git.GetBranches()
+	if err != nil {
+		return branchesErrorMsg{err}
+	}
+	current, _ := git.GetBranch()
+
+	var items []list.Item
+	for _, branch := range branches {
+		// Skip symbolic refs like "remotes/origin/HEAD -> origin/main"
+		if strings.Contains(branch, " -> ") {
+			continue
+		}
+		item := branchItem{name: branch, current: branch == current}
+		// Remote-tracking branches have no upstream of their own
+		if !strings.HasPrefix(branch, "remotes/") {
+			item.ahead, item.behind, _ = git.BranchAheadBehind(branch)
+		}
+		items = append(items, item)
+	}
+	return branchesLoadedMsg{items}
+}
+
+type branchesLoadedMsg struct{ items []list.Item }
+type branchesErrorMsg struct{ err error }
+
+// closeChildMsg tells a hosting view to close its nested sub-view,
+// optionally reporting the outcome like ReturnToMenuMsg does
+type closeChildMsg struct {
+	Message string
+	Type    string
+}
+
+func (m *BranchesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = size.Width
+		m.height = size.Height
+		m.list.SetSize(size.Width, max(size.Height-4, 5))
+	}
+
+	// Route everything to the nested view while it is open
+	if m.child != nil {
+		if closeMsg, ok := msg.(closeChildMsg); ok {
+			m.child = nil
+			if closeMsg.Message == "" {
+				return m, nil
+			}
+			m.message = closeMsg.Message
+			m.msgType = closeMsg.Type
+			return m, m.loadBranches
+		}
+		var cmd tea.Cmd
+		m.child, cmd = m.child.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// Let the list handle typing a filter and clearing it with esc
+		if m.list.SettingFilter() || (msg.String() == "esc" && m.list.IsFiltered()) {
+			break
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "enter", "d":
+			if item, ok := m.list.SelectedItem().(branchItem); ok {
+				m.child = NewBranchDiffModel(item.name, m.width, m.height)
+				return m, m.child.Init()
+			}
+		case "m":
+			if item, ok := m.list.SelectedItem().(branchItem); ok {
+				if item.current {
+					m.message = "Pick another branch to merge into " + item.name
+					m.msgType = "info"
+					return m, nil
+				}
+				m.child = NewMergeModel(m.cfg, item.name)
+				return m, m.child.Init()
+			}
+		case "c":
+			if item, ok := m.list.SelectedItem().(branchItem); ok && !item.current {
+				m.child = NewCherryPickModel(item.name, m.width, m.height)
+				return m, m.child.Init()
+			}
+		case "n":
+			m.child = NewCreateBranchModel(m.cfg)
+			return m, m.child.Init()
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case branchesLoadedMsg:
+		m.state = branchesStateList
+		return m, m.list.SetItems(msg.items)
+
+	case branchesErrorMsg:
+		m.state = branchesStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	if m.state == branchesStateList {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *BranchesModel) View() string {
+	if m.child != nil {
+		return m.child.View()
+	}
+
+	var b strings.Builder
+
+	switch m.state {
+	case branchesStateLoading:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Branch + " Branches"))
+		b.WriteString("\n\n")
+		b.WriteString(m.spinner.View() + " Loading branches...")
+
+	case branchesStateList:
+		b.WriteString(m.list.View())
+		b.WriteString("\n")
+		if m.message != "" {
+			switch m.msgType {
+			case "success":
+				b.WriteString(styles.RenderSuccess(m.message))
+			case "error":
+				b.WriteString(styles.RenderError(m.message))
+			default:
+				b.WriteString(styles.RenderInfo(m.message))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(styles.HelpStyle.Render("enter/d: diff against branch • m: merge • c: cherry-pick • n: new branch • /: filter • esc: back"))
+
+	case branchesStateError:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Branch + " Branches"))
+		b.WriteString("\n\n")
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+type cloneState int
+
+const (
+	cloneStateForm cloneState = iota
+	cloneStateCloning
+	cloneStateError
+)
+
+// CloneModel clones a repository and switches into it
+type CloneModel struct {
+	cfg      *config.Config
+	state    cloneState
+	spinner  spinner.Model
+	form     *huh.Form
+	url      string
+	dir      string
+	progress string
+	err      error
+}
+
+// NewCloneModel creates a new clone view
+func NewCloneModel(cfg *config.Config) *CloneModel {
+	s := newSpinner()
+
+	return &CloneModel{
+		cfg:     cfg,
+		state:   cloneStateForm,
+		spinner: s,
+	}
+}
+
+func (m *CloneModel) Init() tea.Cmd {
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Repository URL").
+				Placeholder("git@github.com:owner/repo.git").
+				Value(&m.url).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("URL cannot be empty")
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title("Directory").
+				Description("Leave empty to use the repository name").
+				Value(&m.dir),
+		),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	return tea.Batch(
+		m.spinner.Tick,
+		m.form.Init(),
+	)
+}
+
+// startClone runs git clone in the background, streaming its progress
+func (m *CloneModel) startClone() tea.Cmd {
+	url := strings.TrimSpace(m.url)
+	dir := strings.TrimSpace(m.dir)
+	if dir == "" {
+		dir = git.CloneDir(url)
+	}
+
+	progress := make(chan string, 64)
+	clone := func() tea.Msg {
+		if err := git.Clone(url, dir, progress); err != nil {
+			return cloneErrorMsg{err}
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return cloneErrorMsg{err}
+		}
+		if err := os.Chdir(abs); err != nil {
+			return cloneErrorMsg{fmt.Errorf("cloned into %s but could not switch to it: %w", abs, err)}
+		}
+		return ReturnToMenuMsg{Message: fmt.Sprintf("Cloned into %s", abs), Type: "success"}
+	}
+	return tea.Batch(clone, waitForCloneProgress(progress))
+}
+
+// waitForCloneProgress delivers the next progress line from git clone
+func waitForCloneProgress(progress <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-progress
+		if !ok {
+			return nil
+		}
+		return cloneProgressMsg{line, progress}
+	}
+}
+
+type cloneProgressMsg struct {
+	line     string
+	progress <-chan string
+}
+
+type cloneErrorMsg struct{ err error }
+
+func (m *CloneModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "esc" {
+			if m.state == cloneStateCloning {
+				git.CancelRunning()
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		}
+		if msg.String() == "r" && m.state == cloneStateError {
+			m.state = cloneStateForm
+			return m, m.Init()
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case cloneProgressMsg:
+		if m.state == cloneStateCloning {
+			m.progress = msg.line
+		}
+		return m, waitForCloneProgress(msg.progress)
+
+	case cloneErrorMsg:
+		m.state = cloneStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	// Update form
+	if m.state == cloneStateForm && m.form != nil {
+		form, cmd := m.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.form = f
+		}
+
+		if m.form.State == huh.StateCompleted {
+			m.state = cloneStateCloning
+			m.progress = ""
+			return m, tea.Batch(m.spinner.Tick, m.startClone())
+		}
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *CloneModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Git + " Clone"))
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case cloneStateForm:
+		if m.form != nil {
+			b.WriteString(m.form.View())
+		}
+
+	case cloneStateCloning:
+		b.WriteString(m.spinner.View() + " Cloning " + strings.TrimSpace(m.url) + "...")
+		if m.progress != "" {
+			b.WriteString("\n\n")
+			b.WriteString(lipgloss.NewStyle().Foreground(styles.TextMuted).Render(m.progress))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("esc: cancel"))
+
+	case cloneStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("r: try again • esc: back"))
+	}
+
+	return b.String()
+}
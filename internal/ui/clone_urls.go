@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// CloneURLsModel shows the SSH and HTTPS clone URLs and copies either one
+type CloneURLsModel struct {
+	remote  git.Remote
+	loaded  bool
+	message string
+	msgType string
+	err     error
+}
+
+// NewCloneURLsModel creates a new clone URL view
+func NewCloneURLsModel() *CloneURLsModel {
+	return &CloneURLsModel{}
+}
+
+func (m *CloneURLsModel) Init() tea.Cmd {
+	return m.loadRemote
+}
+
+// loadRemote parses origin so both URL forms can be built from it
+func (m *CloneURLsModel) loadRemote() tea.Msg {
+	url, err := git.GetRemoteURL()
+	if err != nil {
+		return cloneURLsErrorMsg{fmt.Errorf("no origin remote configured")}
+	}
+	remote, err := git.ParseRemote(url)
+	if err != nil {
+		return cloneURLsErrorMsg{err}
+	}
+	return cloneURLsLoadedMsg{remote}
+}
+
+type cloneURLsLoadedMsg struct{ remote git.Remote }
+type cloneURLsErrorMsg struct{ err error }
+
+func (m *CloneURLsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "s":
+			if m.loaded {
+				m.copy("SSH", m.remote.SSHURL())
+			}
+		case "h":
+			if m.loaded {
+				m.copy("HTTPS", m.remote.HTTPSURL()+".git")
+			}
+		}
+
+	case cloneURLsLoadedMsg:
+		m.remote = msg.remote
+		m.loaded = true
+
+	case cloneURLsErrorMsg:
+		m.err = msg.err
+	}
+
+	return m, nil
+}
+
+// copy puts a URL on the clipboard and reports the outcome inline
+func (m *CloneURLsModel) copy(kind, url string) {
+	if err := clipboard.WriteAll(url); err != nil {
+		m.message = fmt.Sprintf("Could not copy %s URL: %v", kind, err)
+		m.msgType = "error"
+		return
+	}
+	m.message = fmt.Sprintf("Copied %s URL", kind)
+	m.msgType = "success"
+}
+
+func (m *CloneURLsModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Git + " Clone URLs"))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+		return b.String()
+	}
+
+	if !m.loaded {
+		b.WriteString(styles.RenderInfo("Reading origin..."))
+		return b.String()
+	}
+
+	label := lipgloss.NewStyle().Foreground(styles.Purple).Bold(true).Width(7)
+	url := lipgloss.NewStyle().Foreground(styles.TextPrimary)
+	b.WriteString(label.Render("SSH") + url.Render(m.remote.SSHURL()))
+	b.WriteString("\n")
+	b.WriteString(label.Render("HTTPS") + url.Render(m.remote.HTTPSURL()+".git"))
+	b.WriteString("\n\n")
+
+	switch m.msgType {
+	case "success":
+		b.WriteString(styles.RenderSuccess(m.message))
+	case "error":
+		b.WriteString(styles.RenderError(m.message))
+	default:
+		b.WriteString(" ")
+	}
+	b.WriteString("\n\n")
+	b.WriteString(styles.HelpStyle.Render("s: copy SSH • h: copy HTTPS • esc: back"))
+
+	return b.String()
+}
@@ -1,14 +1,20 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/0mykull/gitty/internal/ai"
@@ -22,7 +28,11 @@ type commitState int
 const (
 	commitStateInput commitState = iota
 	commitStateGenerating
+	commitStatePick
 	commitStateConfirm
+	commitStateTicket
+	commitStateIdentity
+	commitStateFinalConfirm
 	commitStateCommitting
 	commitStateDone
 	commitStateNoChanges
@@ -39,17 +49,36 @@ type CommitModel struct {
 	textArea    textarea.Model
 	commitMsg   string
 	renderedMsg string
+	bodyTrimmed bool
 	renderer    *glamour.TermRenderer
 	err         error
 	diff        string
 	ready       bool
+	retrying    bool
+	diffStat    string
+	shortStat   string // scope of the staged changes, shown while confirming
+	leftOut     string // unstaged and untracked files the commit won't include
+	previousMsg string // last AI suggestion, avoided when regenerating
+	noVerify    bool   // skip commit hooks, toggled on the confirm screen
+	candidates  list.Model
+	streamed    string // text received so far while generating
+	stageAll    bool   // stage everything first, like git commit -am
+	notice      string // e.g. why the external editor's result wasn't used
+
+	// Issue link added as a footer, e.g. "Closes #123"
+	ticketForm *huh.Form
+	ticketVerb string
+	ticket     string
+
+	// Author for repos without user.name/user.email
+	identityForm *huh.Form
+	userName     string
+	userEmail    string
 }
 
 // NewCommitModel creates a new commit model
 func NewCommitModel(cfg *config.Config, useAI bool) *CommitModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = styles.SpinnerStyle
+	s := newSpinner()
 
 	ti := textinput.New()
 	ti.Placeholder = "Enter commit message..."
@@ -62,17 +91,31 @@ func NewCommitModel(cfg *config.Config, useAI bool) *CommitModel {
 	ta.SetWidth(60)
 	ta.SetHeight(5)
 
+	l := list.New(nil, candidateDelegate{}, 80, 12)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+	l.DisableQuitKeybindings()
+
 	return &CommitModel{
-		cfg:       cfg,
-		useAI:     useAI,
-		spinner:   s,
-		textInput: ti,
-		textArea:  ta,
-		renderer:  nil, // Will be initialized async
-		ready:     false,
+		cfg:        cfg,
+		candidates: l,
+		useAI:      useAI,
+		spinner:    s,
+		textInput:  ti,
+		textArea:   ta,
+		renderer:   nil, // Will be initialized async
+		ready:      false,
 	}
 }
 
+// WithStageAll makes the commit stage all changes before checking the index
+func (m *CommitModel) WithStageAll() *CommitModel {
+	m.stageAll = true
+	return m
+}
+
 func (m *CommitModel) Init() tea.Cmd {
 	// Start checking status and init renderer in parallel
 	return tea.Batch(
@@ -100,14 +143,30 @@ type rendererMsg struct {
 
 // checkStatusAsync checks git status without blocking
 func (m *CommitModel) checkStatusAsync() tea.Msg {
+	// Stage before checking so a quick commit sees what it just added
+	if m.stageAll {
+		if err := git.AddAll(); err != nil {
+			return commitErrorMsg{fmt.Errorf("failed to stage changes: %w", err)}
+		}
+	}
+
 	// Fast check for staged changes using exit code
 	if !git.HasStagedChanges() {
 		return commitNoChangesMsg{}
 	}
 
+	// Failing to summarize shouldn't block the commit
+	shortStat, _ := git.GetShortStat()
+	leftOut := unstagedSummary()
+
+	// Without an identity git commit fails with a long hint, ask up front
+	if !git.HasIdentity() {
+		return commitNoIdentityMsg{}
+	}
+
 	// For manual commit, we don't need the diff immediately
 	if !m.useAI {
-		return commitReadyMsg{diff: ""}
+		return commitReadyMsg{shortStat: shortStat, leftOut: leftOut}
 	}
 
 	// For AI commit, we need the diff
@@ -116,26 +175,90 @@ func (m *CommitModel) checkStatusAsync() tea.Msg {
 		return commitErrorMsg{err}
 	}
 
-	return commitReadyMsg{diff: diff}
+	return commitReadyMsg{diff: diff, shortStat: shortStat, leftOut: leftOut}
+}
+
+// unstagedSummary counts the changes a commit of the index leaves behind,
+// e.g. "2 modified, 1 untracked"
+func unstagedSummary() string {
+	status, err := git.GetStatus()
+	if err != nil {
+		return ""
+	}
+	var parts []string
+	if n := len(status.ModifiedFiles); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", n))
+	}
+	if n := len(status.UntrackedFiles); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d untracked", n))
+	}
+	return strings.Join(parts, ", ")
 }
 
 type commitReadyMsg struct {
-	diff string
+	diff      string
+	shortStat string
+	leftOut   string
 }
 
 type commitNoChangesMsg struct{}
 
+type commitNoIdentityMsg struct{}
+
 type commitErrorMsg struct {
 	err error
 }
 
+// commitCandidate is a generated message with whether its body was trimmed
+type commitCandidate struct {
+	message     string
+	bodyTrimmed bool
+}
+
+func (c commitCandidate) FilterValue() string { return c.message }
+
+// candidateDelegate renders a candidate's subject with the start of its body
+type candidateDelegate struct{}
+
+func (d candidateDelegate) Height() int                             { return 2 }
+func (d candidateDelegate) Spacing() int                            { return 1 }
+func (d candidateDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d candidateDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	c, ok := listItem.(commitCandidate)
+	if !ok {
+		return
+	}
+
+	subject, body, _ := strings.Cut(c.message, "\n\n")
+	body, _, _ = strings.Cut(strings.TrimSpace(body), "\n")
+	preview := lipgloss.NewStyle().Foreground(styles.TextMuted).Render("     " + body)
+
+	if index == m.Index() {
+		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
+		fmt.Fprint(w, arrow+lipgloss.NewStyle().Foreground(styles.Pink).Bold(true).Render(subject)+"\n"+preview)
+		return
+	}
+	fmt.Fprint(w, "     "+lipgloss.NewStyle().Foreground(styles.TextPrimary).Render(subject)+"\n"+preview)
+}
+
 type commitGeneratedMsg struct {
-	message string
+	candidates []commitCandidate
 }
 
 type commitDoneMsg struct{}
 
+type commitDiffStatMsg struct{ stat string }
+
 func (m *CommitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The issue link form handles its own keys
+	if m.state == commitStateTicket {
+		return m.updateTicketForm(msg)
+	}
+	if m.state == commitStateIdentity {
+		return m.updateIdentityForm(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -171,25 +294,53 @@ func (m *CommitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "y", "Y":
 			if m.state == commitStateConfirm {
+				if _, blocked := m.lintSubject(); blocked {
+					return m, nil
+				}
+			}
+			if m.state == commitStateConfirm && m.useAI && m.cfg.UI.DoubleConfirmAI {
+				m.state = commitStateFinalConfirm
+				return m, m.loadDiffStat
+			}
+			if m.state == commitStateConfirm || m.state == commitStateFinalConfirm {
 				m.state = commitStateCommitting
 				return m, m.doCommit
 			}
 		case "n", "N":
+			if m.state == commitStateFinalConfirm {
+				m.state = commitStateConfirm
+				return m, nil
+			}
 			if m.state == commitStateConfirm {
 				return m, func() tea.Msg {
 					return ReturnToMenuMsg{Message: "Commit cancelled", Type: "info"}
 				}
 			}
-		case "e", "E":
+		case "e":
 			if m.state == commitStateConfirm {
-				// Edit the message
-				m.textInput.SetValue(strings.Split(m.commitMsg, "\n")[0])
-				if parts := strings.SplitN(m.commitMsg, "\n\n", 2); len(parts) > 1 {
-					m.textArea.SetValue(parts[1])
-				}
-				m.textInput.Focus()
-				m.state = commitStateInput
-				return m, textinput.Blink
+				return m.editMessage()
+			}
+		case "E":
+			if m.state == commitStateConfirm {
+				return m.editExternally()
+			}
+		case "t":
+			if m.state == commitStateConfirm {
+				return m, m.initTicketForm()
+			}
+		case "v":
+			if m.state == commitStateConfirm {
+				m.noVerify = !m.noVerify
+				return m, nil
+			}
+		case "r":
+			if m.state == commitStateError {
+				return m.retry()
+			}
+			if m.state == commitStateConfirm && m.useAI {
+				m.previousMsg = m.commitMsg
+				m.state = commitStateGenerating
+				return m, tea.Batch(m.spinner.Tick, m.startGenerating())
 			}
 		}
 
@@ -200,32 +351,84 @@ func (m *CommitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case commitReadyMsg:
 		m.diff = msg.diff
+		m.shortStat = msg.shortStat
+		m.leftOut = msg.leftOut
 		m.ready = true
 
+		// After a failed commit, keep the message instead of starting over
+		if m.retrying && m.commitMsg != "" {
+			m.retrying = false
+			return m.editMessage()
+		}
+		m.retrying = false
+
 		if m.useAI {
 			// For AI commit, start generating immediately
 			m.state = commitStateGenerating
-			return m, m.generateMessage
+			return m, m.startGenerating()
 		}
 		// For manual commit, show input immediately
 		m.state = commitStateInput
 		return m, textinput.Blink
 
+	case commitChunkMsg:
+		// Late chunks after the full message arrived are dropped
+		if m.state == commitStateGenerating {
+			m.streamed += msg.text
+		}
+		return m, waitForChunk(msg.chunks)
+
+	case commitDiffStatMsg:
+		m.diffStat = msg.stat
+		return m, nil
+
+	case commitEditedMsg:
+		switch {
+		case msg.err != nil:
+			m.notice = msg.err.Error()
+		case msg.message == "":
+			m.notice = "The edited message was empty, keeping the previous one"
+		default:
+			m.notice = ""
+			m.commitMsg = msg.message
+			m.bodyTrimmed = false
+			m.renderedMsg = m.renderMessage(m.commitMsg)
+		}
+		return m, nil
+
 	case rendererMsg:
 		m.renderer = msg.renderer
 		return m, nil
 
 	case commitNoChangesMsg:
+		m.retrying = false
 		m.state = commitStateNoChanges
 		return m, nil
 
+	case commitNoIdentityMsg:
+		return m, m.initIdentityForm()
+
 	case commitGeneratedMsg:
-		m.commitMsg = msg.message
-		m.renderedMsg = m.renderMessage(msg.message)
-		m.state = commitStateConfirm
+		if len(msg.candidates) == 1 {
+			return m.pickCandidate(msg.candidates[0])
+		}
+		items := make([]list.Item, len(msg.candidates))
+		for i, c := range msg.candidates {
+			items[i] = c
+		}
+		m.candidates.SetItems(items)
+		m.candidates.Select(0)
+		m.state = commitStatePick
 		return m, nil
 
 	case commitErrorMsg:
+		// Nothing for the AI to go on, so let the user write it instead
+		if errors.Is(msg.err, ai.ErrEmptyDiff) {
+			m.useAI = false
+			m.notice = "The staged changes have no textual diff to summarize, write the message yourself"
+			m.state = commitStateInput
+			return m, textinput.Blink
+		}
 		m.state = commitStateError
 		m.err = msg.err
 		return m, nil
@@ -237,6 +440,12 @@ func (m *CommitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.state == commitStatePick {
+		var cmd tea.Cmd
+		m.candidates, cmd = m.candidates.Update(msg)
+		return m, cmd
+	}
+
 	// Update text inputs when in input state
 	if m.state == commitStateInput {
 		var cmd tea.Cmd
@@ -251,6 +460,71 @@ func (m *CommitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// editMessage loads the current message back into the input fields
+func (m *CommitModel) editMessage() (tea.Model, tea.Cmd) {
+	m.textInput.SetValue(strings.Split(m.commitMsg, "\n")[0])
+	m.textArea.Reset()
+	if parts := strings.SplitN(m.commitMsg, "\n\n", 2); len(parts) > 1 {
+		m.textArea.SetValue(parts[1])
+	}
+	m.textArea.Blur()
+	m.textInput.Focus()
+	m.state = commitStateInput
+	return m, textinput.Blink
+}
+
+// editorHelp is appended below the message in the external editor
+const editorHelp = `
+# Edit the commit message above. Lines starting with '#' are ignored,
+# and saving an empty message keeps the previous one.
+`
+
+type commitEditedMsg struct {
+	message string
+	err     error
+}
+
+// editExternally opens the message in git.editor and reads it back once the
+// editor exits
+func (m *CommitModel) editExternally() (tea.Model, tea.Cmd) {
+	f, err := os.CreateTemp("", "gitty-COMMIT_EDITMSG-*")
+	if err != nil {
+		m.notice = fmt.Sprintf("Cannot open editor: %v", err)
+		return m, nil
+	}
+	path := f.Name()
+	_, err = f.WriteString(m.commitMsg + "\n" + editorHelp)
+	f.Close()
+	if err != nil {
+		os.Remove(path)
+		m.notice = fmt.Sprintf("Cannot open editor: %v", err)
+		return m, nil
+	}
+
+	return m, tea.ExecProcess(editorCommand(m.cfg, path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return commitEditedMsg{err: fmt.Errorf("editor failed: %w", err)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return commitEditedMsg{err: err}
+		}
+		return commitEditedMsg{message: git.CleanupMessage(string(data))}
+	})
+}
+
+// retry re-checks the index after a failure, e.g. a rejecting pre-commit hook.
+// A failed commit leaves staged changes as they were, so the flow resumes from
+// the input step with the previous message.
+func (m *CommitModel) retry() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.ready = false
+	m.retrying = true
+	m.state = commitStateInput
+	return m, tea.Batch(m.spinner.Tick, m.checkStatusAsync)
+}
+
 func (m *CommitModel) submitForm() (tea.Model, tea.Cmd) {
 	title := strings.TrimSpace(m.textInput.Value())
 	if title == "" {
@@ -264,15 +538,187 @@ func (m *CommitModel) submitForm() (tea.Model, tea.Cmd) {
 		m.commitMsg = title
 	}
 	m.renderedMsg = m.renderMessage(m.commitMsg)
+	m.bodyTrimmed = false
+	m.notice = ""
+	m.state = commitStateConfirm
+	return m, nil
+}
+
+// softSubjectLen is where a subject starts getting long for git log --oneline
+const softSubjectLen = 50
+
+// lintSubject checks the subject line, returning a warning and whether it is
+// bad enough to block the commit
+func (m *CommitModel) lintSubject() (string, bool) {
+	subject, _, _ := strings.Cut(m.commitMsg, "\n")
+	subject = strings.TrimSpace(subject)
+	n := utf8.RuneCountInString(subject)
+
+	limit := m.cfg.Git.MaxSubjectLen
+	switch {
+	case subject == "":
+		return "The subject line is empty, press e to edit", true
+	case limit > 0 && n > limit:
+		return fmt.Sprintf("Subject is %d characters, over the %d limit (git.max_subject_len), press e to edit", n, limit), true
+	case n > softSubjectLen:
+		return fmt.Sprintf("Subject is %d characters, try to keep it under %d", n, softSubjectLen), false
+	}
+	return "", false
+}
+
+// initTicketForm asks which issue the commit links to, guessing it from the branch
+func (m *CommitModel) initTicketForm() tea.Cmd {
+	m.ticketVerb = m.cfg.Git.TicketVerb
+	if m.ticketVerb == "" {
+		m.ticketVerb = "Closes"
+	}
+	if m.ticket == "" {
+		if branch, err := git.GetBranch(); err == nil {
+			m.ticket = git.TicketFromBranch(branch)
+		}
+	}
+
+	m.ticketForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Link issue").
+				Options(
+					huh.NewOption("Closes", "Closes"),
+					huh.NewOption("Fixes", "Fixes"),
+					huh.NewOption("Refs", "Refs"),
+				).
+				Value(&m.ticketVerb),
+
+			huh.NewInput().
+				Title("Issue").
+				Description("e.g. #123 or PROJ-456").
+				Value(&m.ticket),
+		),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	m.state = commitStateTicket
+	return m.ticketForm.Init()
+}
+
+func (m *CommitModel) updateTicketForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			m.state = commitStateConfirm
+			return m, nil
+		}
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	form, cmd := m.ticketForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.ticketForm = f
+	}
+
+	if m.ticketForm.State == huh.StateCompleted {
+		if ticket := git.NormalizeTicket(m.ticket); ticket != "" {
+			m.commitMsg = git.AppendTrailer(m.commitMsg, m.ticketVerb+" "+ticket)
+			m.renderedMsg = m.renderMessage(m.commitMsg)
+		}
+		m.state = commitStateConfirm
+		return m, nil
+	}
+
+	return m, cmd
+}
+
+// initIdentityForm asks for the author git is missing, prefilled from the config
+func (m *CommitModel) initIdentityForm() tea.Cmd {
+	m.userName = m.cfg.Git.UserName
+	m.userEmail = m.cfg.Git.UserEmail
+	required := func(field string) func(string) error {
+		return func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return fmt.Errorf("%s cannot be empty", field)
+			}
+			return nil
+		}
+	}
+
+	m.identityForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Git user name").
+				Value(&m.userName).
+				Validate(required("name")),
+
+			huh.NewInput().
+				Title("Git user email").
+				Description("Saved to this repository's git config").
+				Value(&m.userEmail).
+				Validate(required("email")),
+		),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	m.state = commitStateIdentity
+	return m.identityForm.Init()
+}
+
+func (m *CommitModel) updateIdentityForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "esc" {
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "Cancelled", Type: "info"}
+			}
+		}
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	form, cmd := m.identityForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.identityForm = f
+	}
+
+	if m.identityForm.State == huh.StateCompleted {
+		m.state = commitStateInput
+		m.ready = false
+		name, email := strings.TrimSpace(m.userName), strings.TrimSpace(m.userEmail)
+		return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+			if err := git.SetUser(name, email); err != nil {
+				return commitErrorMsg{fmt.Errorf("failed to set git identity: %w", err)}
+			}
+			return m.checkStatusAsync()
+		})
+	}
+
+	return m, cmd
+}
+
+// pickCandidate takes a generated message on to the confirm step
+func (m *CommitModel) pickCandidate(c commitCandidate) (tea.Model, tea.Cmd) {
+	m.commitMsg = c.message
+	m.bodyTrimmed = c.bodyTrimmed
+	m.renderedMsg = m.renderMessage(c.message)
 	m.state = commitStateConfirm
 	return m, nil
 }
 
 func (m *CommitModel) handleEnter() (tea.Model, tea.Cmd) {
 	switch m.state {
+	case commitStatePick:
+		if c, ok := m.candidates.SelectedItem().(commitCandidate); ok {
+			return m.pickCandidate(c)
+		}
+
 	case commitStateNoChanges:
+		message := "No staged changes to commit"
+		if m.stageAll {
+			message = "Nothing to commit, working tree clean"
+		}
 		return m, func() tea.Msg {
-			return ReturnToMenuMsg{Message: "No staged changes to commit", Type: "info"}
+			return ReturnToMenuMsg{Message: message, Type: "info"}
 		}
 
 	case commitStateError:
@@ -284,16 +730,68 @@ func (m *CommitModel) handleEnter() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// startGenerating streams a single suggestion, or asks for several
+// candidates at once when ai.candidates is above 1
+func (m *CommitModel) startGenerating() tea.Cmd {
+	m.streamed = ""
+	if m.cfg.AI.Candidates > 1 {
+		return m.generateMessage
+	}
+
+	// Buffered so a stream abandoned with esc never blocks on send
+	chunks := make(chan string, 4096)
+	stream := func() tea.Msg {
+		msg, err := ai.StreamCommitMessage(m.diff, m.previousMsg, m.cfg, chunks)
+		if err != nil {
+			return commitErrorMsg{err}
+		}
+		msg, trimmed := ai.LimitBody(msg, m.cfg.AI.MaxBodyLines)
+		return commitGeneratedMsg{[]commitCandidate{{msg, trimmed}}}
+	}
+	return tea.Batch(stream, waitForChunk(chunks))
+}
+
+// waitForChunk delivers the next piece of a streamed message
+func waitForChunk(chunks <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		text, ok := <-chunks
+		if !ok {
+			return nil
+		}
+		return commitChunkMsg{text, chunks}
+	}
+}
+
+type commitChunkMsg struct {
+	text   string
+	chunks <-chan string
+}
+
 func (m *CommitModel) generateMessage() tea.Msg {
-	msg, err := ai.GenerateCommitMessage(m.diff, m.cfg)
+	messages, err := ai.GenerateCandidates(m.diff, m.previousMsg, m.cfg)
 	if err != nil {
 		return commitErrorMsg{err}
 	}
-	return commitGeneratedMsg{msg}
+
+	candidates := make([]commitCandidate, len(messages))
+	for i, msg := range messages {
+		msg, trimmed := ai.LimitBody(msg, m.cfg.AI.MaxBodyLines)
+		candidates[i] = commitCandidate{msg, trimmed}
+	}
+	return commitGeneratedMsg{candidates}
+}
+
+// loadDiffStat fetches the staged diff stat for the final confirmation
+func (m *CommitModel) loadDiffStat() tea.Msg {
+	stat, err := git.GetDiffStat()
+	if err != nil {
+		return commitDiffStatMsg{"(diff stat unavailable)"}
+	}
+	return commitDiffStatMsg{stat}
 }
 
 func (m *CommitModel) doCommit() tea.Msg {
-	if err := git.Commit(m.commitMsg); err != nil {
+	if err := git.Commit(m.commitMsg, git.CommitOptions{Sign: m.cfg.Git.SignCommits, NoVerify: m.noVerify}); err != nil {
 		return commitErrorMsg{err}
 	}
 	return commitDoneMsg{}
@@ -326,10 +824,14 @@ func (m *CommitModel) View() string {
 
 	switch m.state {
 	case commitStateInput:
-		if !m.ready && !m.useAI {
+		if !m.ready {
 			// Still loading, show spinner briefly
 			b.WriteString(m.spinner.View() + " Checking status...")
 		} else {
+			if m.notice != "" {
+				b.WriteString(styles.RenderInfo(m.notice))
+				b.WriteString("\n\n")
+			}
 			b.WriteString("Enter your commit message:\n\n")
 			b.WriteString(lipgloss.NewStyle().Foreground(styles.Purple).Render("Title:") + "\n")
 			b.WriteString(m.textInput.View())
@@ -341,15 +843,41 @@ func (m *CommitModel) View() string {
 		}
 
 	case commitStateGenerating:
-		b.WriteString(m.spinner.View() + " Generating commit message with AI...")
+		if host := ai.EndpointHost(m.cfg); host != "" {
+			// A custom endpoint gets the API key, so say which one
+			b.WriteString(m.spinner.View() + " Generating commit message with AI via " + host + "...")
+		} else {
+			b.WriteString(m.spinner.View() + " Generating commit message with AI...")
+		}
+		b.WriteString("\n")
+		if m.streamed != "" {
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().
+				BorderStyle(lipgloss.RoundedBorder()).
+				BorderForeground(styles.TextMuted).
+				Padding(0, 1).
+				Render(m.streamed))
+		} else {
+			b.WriteString(styles.HelpStyle.Render("This may take a few seconds..."))
+		}
+
+	case commitStatePick:
+		b.WriteString("Pick a commit message:\n\n")
+		b.WriteString(m.candidates.View())
 		b.WriteString("\n")
-		b.WriteString(styles.HelpStyle.Render("This may take a few seconds..."))
+		b.WriteString(styles.HelpStyle.Render("↑↓: choose • enter: use message • esc: cancel"))
 
 	case commitStateNoChanges:
-		b.WriteString(styles.WarningStyle.Render(styles.Icons.Warning + " No staged changes"))
-		b.WriteString("\n\n")
-		b.WriteString("You need to stage changes before committing.\n")
-		b.WriteString("Use 'Stage All' (a) from the menu or 'git add <file>'.")
+		if m.stageAll {
+			b.WriteString(styles.WarningStyle.Render(styles.Icons.Warning + " Nothing to commit"))
+			b.WriteString("\n\n")
+			b.WriteString("The working tree has no changes to stage.")
+		} else {
+			b.WriteString(styles.WarningStyle.Render(styles.Icons.Warning + " No staged changes"))
+			b.WriteString("\n\n")
+			b.WriteString("You need to stage changes before committing.\n")
+			b.WriteString("Use 'Stage All' (a) from the menu, 'Quick Commit' (C) or 'git add <file>'.")
+		}
 		b.WriteString("\n\n")
 		b.WriteString(styles.HelpStyle.Render("Press enter or esc to go back"))
 
@@ -361,10 +889,79 @@ func (m *CommitModel) View() string {
 			Padding(1, 2).
 			Render(m.renderedMsg)
 		b.WriteString(box)
-		b.WriteString("\n\n")
+		b.WriteString("\n")
+		if m.shortStat != "" {
+			b.WriteString(lipgloss.NewStyle().Foreground(styles.TextMuted).Render("  " + m.shortStat))
+			b.WriteString("\n")
+		}
+		if m.leftOut != "" {
+			b.WriteString(lipgloss.NewStyle().Foreground(styles.Yellow).Render("  " + m.leftOut + " file(s) will NOT be included"))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if m.bodyTrimmed {
+			b.WriteString(styles.RenderWarning(fmt.Sprintf("Body trimmed to %d lines (max_body_lines)", m.cfg.AI.MaxBodyLines)))
+			b.WriteString("\n")
+		}
+		if m.cfg.Git.SignCommits {
+			b.WriteString(styles.RenderInfo("Commit will be GPG-signed"))
+			b.WriteString("\n")
+		}
+		if m.noVerify {
+			b.WriteString(styles.RenderWarning("Hooks will be skipped (--no-verify)"))
+			b.WriteString("\n")
+		}
+		if m.notice != "" {
+			b.WriteString(styles.RenderWarning(m.notice))
+			b.WriteString("\n")
+		}
+		if lint, blocked := m.lintSubject(); blocked {
+			b.WriteString(styles.RenderError(lint))
+			b.WriteString("\n")
+		} else if lint != "" {
+			b.WriteString(styles.RenderWarning(lint))
+			b.WriteString("\n")
+		}
 		b.WriteString(styles.InfoStyle.Render("Commit with this message?"))
 		b.WriteString("\n")
-		b.WriteString(styles.HelpStyle.Render("y: confirm • n: cancel • e: edit"))
+		if m.useAI {
+			b.WriteString(styles.HelpStyle.Render("y: confirm • n: cancel • e: edit • E: editor • r: regenerate • t: link issue • v: skip hooks"))
+		} else {
+			b.WriteString(styles.HelpStyle.Render("y: confirm • n: cancel • e: edit • E: editor • t: link issue • v: skip hooks"))
+		}
+
+	case commitStateFinalConfirm:
+		b.WriteString(styles.WarningStyle.Render(styles.Icons.Warning + " This will create a commit now"))
+		b.WriteString("\n\n")
+		if m.diffStat == "" {
+			b.WriteString(m.spinner.View() + " Loading diff stat...")
+		} else {
+			b.WriteString(lipgloss.NewStyle().Foreground(styles.TextMuted).Render(m.diffStat))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(styles.Yellow).
+			Padding(0, 1).
+			Render(m.commitMsg))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("y: commit now • n: back • esc: cancel"))
+
+	case commitStateIdentity:
+		b.WriteString(styles.RenderWarning("Git doesn't know who you are, set an author to commit"))
+		b.WriteString("\n\n")
+		if m.identityForm != nil {
+			b.WriteString(m.identityForm.View())
+		}
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("esc: cancel"))
+
+	case commitStateTicket:
+		if m.ticketForm != nil {
+			b.WriteString(m.ticketForm.View())
+		}
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("esc: back to message"))
 
 	case commitStateCommitting:
 		b.WriteString(m.spinner.View() + " Committing changes...")
@@ -375,7 +972,11 @@ func (m *CommitModel) View() string {
 	case commitStateError:
 		b.WriteString(styles.RenderError(fmt.Sprintf("Error: %v", m.err)))
 		b.WriteString("\n\n")
-		b.WriteString(styles.HelpStyle.Render("Press enter or esc to go back"))
+		if m.commitMsg != "" {
+			b.WriteString(styles.RenderInfo("Nothing was committed and your staged changes are untouched"))
+			b.WriteString("\n\n")
+		}
+		b.WriteString(styles.HelpStyle.Render("r: retry • enter/esc: back to menu"))
 	}
 
 	return b.String()
@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// ConfigModel edits the common config fields and saves them
+type ConfigModel struct {
+	cfg  *config.Config
+	form *huh.Form
+	err  error
+
+	// Edited copies, applied to cfg only when saved
+	provider   string
+	model      string
+	apiKey     string
+	userName   string
+	userEmail  string
+	visibility string
+	theme      string
+}
+
+// NewConfigModel creates a new config editor
+func NewConfigModel(cfg *config.Config) *ConfigModel {
+	return &ConfigModel{
+		cfg:        cfg,
+		provider:   cfg.AI.Provider,
+		model:      cfg.AI.Model,
+		apiKey:     cfg.AI.APIKey,
+		userName:   cfg.Git.UserName,
+		userEmail:  cfg.Git.UserEmail,
+		visibility: cfg.GitHub.DefaultVisibility,
+		theme:      cfg.UI.Theme,
+	}
+}
+
+func (m *ConfigModel) Init() tea.Cmd {
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("AI provider").
+				Options(
+					huh.NewOption("OpenAI", "openai"),
+					huh.NewOption("Anthropic", "anthropic"),
+					huh.NewOption("Gemini", "gemini"),
+				).
+				Value(&m.provider),
+
+			huh.NewInput().
+				Title("AI model").
+				Value(&m.model),
+
+			huh.NewInput().
+				Title("API key").
+				Description(apiKeyDescription(m.cfg.AI.APIKey)).
+				EchoMode(huh.EchoModePassword).
+				Value(&m.apiKey),
+		).Title("AI"),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Git user name").
+				Value(&m.userName),
+
+			huh.NewInput().
+				Title("Git user email").
+				Value(&m.userEmail),
+		).Title("Git"),
+
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Default visibility").
+				Options(
+					huh.NewOption("Public", "public"),
+					huh.NewOption("Private", "private"),
+				).
+				Value(&m.visibility),
+
+			huh.NewSelect[string]().
+				Title("Theme").
+				Options(
+					huh.NewOption("Charm", "charm"),
+					huh.NewOption("Dracula", "dracula"),
+					huh.NewOption("Catppuccin", "catppuccin"),
+				).
+				Value(&m.theme),
+		).Title("Appearance"),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	return m.form.Init()
+}
+
+// apiKeyDescription tells which key is set without revealing it
+func apiKeyDescription(key string) string {
+	if key == "" {
+		return "Not set"
+	}
+	return "Current: " + config.RedactKey(key)
+}
+
+func (m *ConfigModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "ctrl+e":
+			// Everything the form doesn't cover, e.g. keybindings
+			return m, tea.ExecProcess(editorCommand(m.cfg, config.ConfigPath()), m.reload)
+		}
+
+	case configReloadErrorMsg:
+		m.err = msg.err
+		return m, nil
+	}
+
+	if m.form == nil || m.err != nil {
+		return m, nil
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+
+	if m.form.State == huh.StateCompleted {
+		return m, m.save()
+	}
+
+	return m, cmd
+}
+
+// save applies the edits to the shared config, so every view sees them
+// immediately, then writes the file
+func (m *ConfigModel) save() tea.Cmd {
+	m.cfg.AI.Provider = m.provider
+	m.cfg.AI.Model = strings.TrimSpace(m.model)
+	m.cfg.AI.APIKey = strings.TrimSpace(m.apiKey)
+	// A key from the environment belongs to the provider it was looked up for
+	_ = config.ResolveAPIKey(m.cfg)
+	m.cfg.Git.UserName = strings.TrimSpace(m.userName)
+	m.cfg.Git.UserEmail = strings.TrimSpace(m.userEmail)
+	m.cfg.GitHub.DefaultVisibility = m.visibility
+	m.cfg.UI.Theme = m.theme
+
+	styles.Apply(styles.ThemeFor(m.cfg.UI.Theme))
+
+	if err := config.Save(m.cfg); err != nil {
+		m.err = err
+		return nil
+	}
+
+	return func() tea.Msg {
+		return ReturnToMenuMsg{Message: "Config saved", Type: "success"}
+	}
+}
+
+type configReloadErrorMsg struct{ err error }
+
+// reload re-reads the config file after it was edited outside gitty
+func (m *ConfigModel) reload(err error) tea.Msg {
+	if err != nil {
+		return configReloadErrorMsg{fmt.Errorf("editor failed: %w", err)}
+	}
+	cfg, err := config.Load()
+	if err != nil && !errors.Is(err, config.ErrLocalConfig) && !errors.Is(err, config.ErrAPIKey) {
+		return configReloadErrorMsg{err}
+	}
+	*m.cfg = *cfg
+	// Settings main applied at startup; the menu rebuilds its items on return
+	styles.Apply(styles.ThemeFor(m.cfg.UI.Theme))
+	styles.Icons = styles.IconsFor(m.cfg.UI.ShowIcons)
+	SetAnimation(m.cfg.UI.AnimationMs)
+	git.SetCommandTimeout(time.Duration(m.cfg.Git.CommandTimeoutMs) * time.Millisecond)
+	return ReturnToMenuMsg{Message: "Config reloaded", Type: "success"}
+}
+
+func (m *ConfigModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Config + " Config"))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(styles.RenderError(fmt.Sprintf("Config error: %v", m.err)))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+		return b.String()
+	}
+
+	if m.form != nil {
+		b.WriteString(m.form.View())
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.HelpStyle.Render("Saved to " + config.ConfigPath() + " • ctrl+e: open in editor • esc: cancel"))
+
+	return b.String()
+}
@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// conflictItem implements list.Item
+type conflictItem string
+
+func (i conflictItem) FilterValue() string { return string(i) }
+
+// conflictDelegate renders conflicted paths in red
+type conflictDelegate struct{}
+
+func (d conflictDelegate) Height() int                             { return 1 }
+func (d conflictDelegate) Spacing() int                            { return 0 }
+func (d conflictDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d conflictDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(conflictItem)
+	if !ok {
+		return
+	}
+
+	var line string
+	if index == m.Index() {
+		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
+		line = arrow + lipgloss.NewStyle().Foreground(styles.Red).Bold(true).Render(string(i))
+	} else {
+		line = "     " + lipgloss.NewStyle().Foreground(styles.Red).Render(string(i))
+	}
+
+	fmt.Fprint(w, line)
+}
+
+type conflictsState int
+
+const (
+	conflictsStateLoading conflictsState = iota
+	conflictsStateList
+	conflictsStateConfirm
+	conflictsStateWorking
+	conflictsStateError
+)
+
+// ConflictsModel lists unmerged files, marks them resolved or aborts the
+// merge, rebase or cherry-pick that left them
+type ConflictsModel struct {
+	cfg       *config.Config
+	state     conflictsState
+	spinner   spinner.Model
+	list      list.Model
+	form      *huh.Form
+	operation string
+	confirmed bool
+	err       error
+	message   string
+	msgType   string
+}
+
+// NewConflictsModel creates a new conflict view
+func NewConflictsModel(cfg *config.Config, width, height int) *ConflictsModel {
+	s := newSpinner()
+
+	l := list.New(nil, conflictDelegate{}, width, max(height-4, 5))
+	l.Title = "Conflicts"
+	l.Styles.Title = styles.TitleStyle
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.DisableQuitKeybindings()
+
+	return &ConflictsModel{
+		cfg:     cfg,
+		state:   conflictsStateLoading,
+		spinner: s,
+		list:    l,
+	}
+}
+
+func (m *ConflictsModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadConflicts,
+	)
+}
+
+func (m *ConflictsModel) loadConflicts() tea.Msg {
+	files, err := git.ConflictedFiles()
+	if err != nil {
+		return conflictsErrorMsg{err}
+	}
+	return conflictsLoadedMsg{files, git.InProgressOperation()}
+}
+
+func (m *ConflictsModel) markResolved(file string) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.MarkResolved(file); err != nil {
+			return conflictChangedMsg{fmt.Sprintf("Failed to mark %s resolved: %v", file, err), "error"}
+		}
+		return conflictChangedMsg{fmt.Sprintf("Marked %s resolved", file), "success"}
+	}
+}
+
+func (m *ConflictsModel) doAbort() tea.Msg {
+	if err := git.AbortOperation(); err != nil {
+		return conflictsErrorMsg{fmt.Errorf("failed to abort: %w", err)}
+	}
+	return ReturnToMenuMsg{Message: fmt.Sprintf("Aborted the %s", m.operation), Type: "info"}
+}
+
+type conflictsLoadedMsg struct {
+	files     []string
+	operation string
+}
+type conflictsErrorMsg struct{ err error }
+type conflictChangedMsg struct{ message, msgType string }
+
+// initAbortForm confirms abandoning the operation in progress
+func (m *ConflictsModel) initAbortForm() tea.Cmd {
+	m.confirmed = false
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Abort the %s?", m.operation)).
+				Description(fmt.Sprintf("This runs git %s --abort and drops any resolutions made so far", m.operation)).
+				Affirmative("Abort").
+				Negative("Cancel").
+				Value(&m.confirmed),
+		),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	m.state = conflictsStateConfirm
+	return m.form.Init()
+}
+
+// resolvedMessage says how to finish once no conflicts are left
+func resolvedMessage(operation string) string {
+	switch operation {
+	case "rebase":
+		return "All conflicts resolved, run git rebase --continue to finish"
+	case "merge", "cherry-pick":
+		return fmt.Sprintf("All conflicts resolved, commit to finish the %s", operation)
+	}
+	return "No conflicts"
+}
+
+func (m *ConflictsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, max(msg.Height-4, 5))
+
+	case tea.KeyMsg:
+		if m.state == conflictsStateConfirm {
+			if msg.String() == "esc" {
+				m.state = conflictsStateList
+				return m, nil
+			}
+			break
+		}
+
+		// Let the list handle typing a filter and clearing it with esc
+		if m.list.SettingFilter() || (msg.String() == "esc" && m.list.IsFiltered()) {
+			break
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			if m.state == conflictsStateWorking {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "s", "enter":
+			if item, ok := m.list.SelectedItem().(conflictItem); ok && m.state == conflictsStateList {
+				m.state = conflictsStateWorking
+				return m, tea.Batch(m.spinner.Tick, m.markResolved(string(item)))
+			}
+		case "e":
+			if item, ok := m.list.SelectedItem().(conflictItem); ok && m.state == conflictsStateList {
+				return m, tea.ExecProcess(editorCommand(m.cfg, string(item)), func(err error) tea.Msg {
+					if err != nil {
+						return conflictChangedMsg{fmt.Sprintf("Editor failed: %v", err), "error"}
+					}
+					return conflictChangedMsg{}
+				})
+			}
+		case "a":
+			if m.state == conflictsStateList && m.operation != "" {
+				return m, m.initAbortForm()
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case conflictsLoadedMsg:
+		m.operation = msg.operation
+		if len(msg.files) == 0 {
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: resolvedMessage(msg.operation), Type: "info"}
+			}
+		}
+		m.state = conflictsStateList
+		items := make([]list.Item, len(msg.files))
+		for i, file := range msg.files {
+			items[i] = conflictItem(file)
+		}
+		return m, m.list.SetItems(items)
+
+	case conflictChangedMsg:
+		m.message = msg.message
+		m.msgType = msg.msgType
+		m.state = conflictsStateLoading
+		return m, m.loadConflicts
+
+	case conflictsErrorMsg:
+		m.state = conflictsStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	if m.state == conflictsStateConfirm && m.form != nil {
+		form, cmd := m.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.form = f
+		}
+
+		if m.form.State == huh.StateCompleted {
+			if !m.confirmed {
+				m.state = conflictsStateList
+				return m, nil
+			}
+			m.state = conflictsStateWorking
+			return m, tea.Batch(m.spinner.Tick, m.doAbort)
+		}
+
+		return m, cmd
+	}
+
+	if m.state == conflictsStateList {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *ConflictsModel) View() string {
+	var b strings.Builder
+
+	if m.state == conflictsStateList {
+		b.WriteString(m.list.View())
+		b.WriteString("\n")
+		switch m.msgType {
+		case "success":
+			b.WriteString(styles.RenderSuccess(m.message) + "\n")
+		case "error":
+			b.WriteString(styles.RenderError(m.message) + "\n")
+		}
+		help := "e: edit • s: mark resolved • /: filter • esc: back"
+		if m.operation != "" {
+			help = fmt.Sprintf("e: edit • s: mark resolved • a: abort %s • /: filter • esc: back", m.operation)
+		}
+		b.WriteString(styles.HelpStyle.Render(help))
+		return b.String()
+	}
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Warning + " Conflicts"))
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case conflictsStateLoading:
+		b.WriteString(m.spinner.View() + " Loading conflicts...")
+
+	case conflictsStateConfirm:
+		if m.form != nil {
+			b.WriteString(m.form.View())
+		}
+
+	case conflictsStateWorking:
+		b.WriteString(m.spinner.View() + " Working...")
+
+	case conflictsStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
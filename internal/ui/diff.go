@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// diffChromeHeight is the number of lines used by the title and help
+const diffChromeHeight = 6
+
+type diffMode int
+
+const (
+	diffModeStaged diffMode = iota
+	diffModeFull
+	diffModeBranch
+)
+
+// DiffModel shows a syntax highlighted diff in a scrollable viewport
+type DiffModel struct {
+	mode     diffMode
+	branch   string
+	statOnly bool
+	nested   bool
+	spinner  spinner.Model
+	viewport viewport.Model
+	loading  bool
+	empty    bool
+	err      error
+}
+
+// NewDiffModel creates a diff view of the staged changes
+func NewDiffModel(width, height int) *DiffModel {
+	s := newSpinner()
+
+	return &DiffModel{
+		mode:     diffModeStaged,
+		spinner:  s,
+		viewport: viewport.New(width, max(height-diffChromeHeight, 3)),
+		loading:  true,
+	}
+}
+
+// NewBranchDiffModel creates a diff view comparing the working tree to branch.
+// It is opened from the branch view, so esc returns there instead of the menu.
+func NewBranchDiffModel(branch string, width, height int) *DiffModel {
+	m := NewDiffModel(width, height)
+	m.mode = diffModeBranch
+	m.branch = branch
+	m.nested = true
+	return m
+}
+
+func (m *DiffModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadDiff(),
+	)
+}
+
+// loadDiff fetches and renders the diff in the background, since large
+// diffs are slow both to produce and to highlight
+func (m *DiffModel) loadDiff() tea.Cmd {
+	mode, branch, statOnly, width := m.mode, m.branch, m.statOnly, m.viewport.Width
+	return func() tea.Msg {
+		var diff string
+		var err error
+		switch mode {
+		case diffModeStaged:
+			diff, err = git.GetDiff()
+		case diffModeFull:
+			diff, err = git.GetFullDiff()
+		case diffModeBranch:
+			diff, err = git.DiffBranch(branch, statOnly)
+		}
+		if err != nil || statOnly {
+			return diffLoadedMsg{mode: mode, statOnly: statOnly, diff: diff, err: err}
+		}
+		return diffLoadedMsg{mode: mode, statOnly: statOnly, diff: diff, rendered: renderDiff(diff, width)}
+	}
+}
+
+// renderDiff highlights a diff through glamour, falling back to plain text
+func renderDiff(diff string, width int) string {
+	if strings.TrimSpace(diff) == "" {
+		return diff
+	}
+
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle("dark"),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return diff
+	}
+
+	out, err := r.Render("```diff\n" + diff + "\n```")
+	if err != nil {
+		return diff
+	}
+	return out
+}
+
+type diffLoadedMsg struct {
+	mode     diffMode
+	statOnly bool
+	diff     string
+	rendered string
+	err      error
+}
+
+func (m *DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			if m.nested {
+				return m, func() tea.Msg { return closeChildMsg{} }
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "s":
+			if m.mode == diffModeBranch {
+				m.statOnly = !m.statOnly
+				m.loading = true
+				return m, m.loadDiff()
+			}
+		case "t":
+			if m.mode != diffModeBranch {
+				if m.mode == diffModeStaged {
+					m.mode = diffModeFull
+				} else {
+					m.mode = diffModeStaged
+				}
+				m.loading = true
+				return m, m.loadDiff()
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-diffChromeHeight, 3)
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case diffLoadedMsg:
+		// Ignore results from a mode that has since been toggled away
+		if msg.mode != m.mode || msg.statOnly != m.statOnly {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		m.empty = strings.TrimSpace(msg.diff) == ""
+		if msg.rendered != "" {
+			m.viewport.SetContent(msg.rendered)
+		} else {
+			m.viewport.SetContent(msg.diff)
+		}
+		m.viewport.GotoTop()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m *DiffModel) title() string {
+	switch m.mode {
+	case diffModeFull:
+		return styles.Icons.Diff + " Diff (staged + unstaged)"
+	case diffModeBranch:
+		return fmt.Sprintf("%s Diff against %s", styles.Icons.Branch, m.branch)
+	default:
+		return styles.Icons.Diff + " Diff (staged)"
+	}
+}
+
+func (m *DiffModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(m.title()))
+	if m.mode == diffModeBranch {
+		mode := "full"
+		if m.statOnly {
+			mode = "stat"
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.TextMuted).Render(" (" + mode + ")"))
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(m.spinner.View() + " Loading diff...")
+	case m.err != nil:
+		b.WriteString(styles.RenderError(m.err.Error()))
+	case m.empty:
+		b.WriteString(styles.RenderInfo("No differences"))
+	default:
+		b.WriteString(m.viewport.View())
+	}
+
+	help := "↑↓/pgup/pgdn: scroll • t: toggle staged/full • esc: back"
+	if m.mode == diffModeBranch {
+		help = "↑↓/pgup/pgdn: scroll • s: toggle stat • esc: back"
+	}
+	b.WriteString("\n\n")
+	b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("%s  %3.f%%", help, m.viewport.ScrollPercent()*100)))
+
+	return b.String()
+}
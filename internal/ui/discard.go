@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// maxDiscardPreview caps how many files are listed in the confirmation
+const maxDiscardPreview = 15
+
+type discardState int
+
+const (
+	discardStateLoading discardState = iota
+	discardStateConfirm
+	discardStateWorking
+	discardStateDone
+	discardStateError
+)
+
+// DiscardModel handles removing untracked files while keeping tracked edits
+type DiscardModel struct {
+	cfg       *config.Config
+	state     discardState
+	spinner   spinner.Model
+	form      *huh.Form
+	files     []string
+	confirmed bool
+	err       error
+}
+
+// NewDiscardModel creates a new discard untracked files model
+func NewDiscardModel(cfg *config.Config) *DiscardModel {
+	s := newSpinner()
+
+	return &DiscardModel{
+		cfg:       cfg,
+		state:     discardStateLoading,
+		spinner:   s,
+		confirmed: false,
+	}
+}
+
+func (m *DiscardModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadPreview,
+	)
+}
+
+// loadPreview asks git which files would be removed
+func (m *DiscardModel) loadPreview() tea.Msg {
+	files, err := git.UntrackedToClean()
+	if err != nil {
+		return discardErrorMsg{err}
+	}
+	return discardPreviewMsg{files}
+}
+
+type discardPreviewMsg struct{ files []string }
+type discardDoneMsg struct{}
+type discardErrorMsg struct{ err error }
+
+func (m *DiscardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "esc" {
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case discardPreviewMsg:
+		if len(msg.files) == 0 {
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "No untracked files to discard", Type: "info"}
+			}
+		}
+		m.files = msg.files
+		m.state = discardStateConfirm
+		return m, m.initForm()
+
+	case discardDoneMsg:
+		m.state = discardStateDone
+		return m, func() tea.Msg {
+			return ReturnToMenuMsg{
+				Message: fmt.Sprintf("Discarded %d untracked file(s)", len(m.files)),
+				Type:    "success",
+			}
+		}
+
+	case discardErrorMsg:
+		m.state = discardStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	// Update form
+	if m.state == discardStateConfirm && m.form != nil {
+		form, cmd := m.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.form = f
+		}
+
+		if m.form.State == huh.StateCompleted {
+			if m.confirmed {
+				m.state = discardStateWorking
+				return m, m.doDiscard
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "Discard cancelled", Type: "info"}
+			}
+		}
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *DiscardModel) initForm() tea.Cmd {
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Discard %d untracked file(s)?", len(m.files))).
+				Description(m.previewList()).
+				Affirmative("Yes, discard").
+				Negative("Cancel").
+				Value(&m.confirmed),
+		),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	return m.form.Init()
+}
+
+// previewList renders the files that will be removed, capped for long lists
+func (m *DiscardModel) previewList() string {
+	var lines []string
+	for i, file := range m.files {
+		if i == maxDiscardPreview {
+			lines = append(lines, fmt.Sprintf("  ...and %d more", len(m.files)-maxDiscardPreview))
+			break
+		}
+		lines = append(lines, "  "+file)
+	}
+	lines = append(lines, "", "Tracked changes are kept (git clean -f)")
+	return strings.Join(lines, "\n")
+}
+
+func (m *DiscardModel) doDiscard() tea.Msg {
+	if err := git.CleanUntracked(m.files); err != nil {
+		return discardErrorMsg{err}
+	}
+	return discardDoneMsg{}
+}
+
+func (m *DiscardModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Trash + " Discard Untracked"))
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case discardStateLoading:
+		b.WriteString(m.spinner.View() + " Checking untracked files...")
+
+	case discardStateConfirm:
+		if m.form != nil {
+			b.WriteString(m.form.View())
+		}
+
+	case discardStateWorking:
+		b.WriteString(m.spinner.View() + " Discarding...")
+
+	case discardStateDone:
+		b.WriteString(styles.RenderSuccess("Untracked files discarded"))
+
+	case discardStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/0mykull/gitty/internal/config"
+)
+
+// editorCommand opens path in git.editor, e.g. "code --wait", falling back
+// to $VISUAL, $EDITOR and then vim like git does
+func editorCommand(cfg *config.Config, path string) *exec.Cmd {
+	editor := strings.TrimSpace(cfg.Git.Editor)
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor == "" {
+			editor = strings.TrimSpace(os.Getenv(env))
+		}
+	}
+	if editor == "" {
+		editor = "vim"
+	}
+	fields := strings.Fields(editor)
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
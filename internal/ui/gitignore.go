@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/0mykull/gitty/internal/git"
+)
+
+// gitignoreTemplate is a bundled .gitignore offered when publishing
+type gitignoreTemplate struct {
+	name    string
+	content string
+}
+
+// gitignoreTemplates are trimmed down from github/gitignore
+var gitignoreTemplates = []gitignoreTemplate{
+	{"Go", `# Binaries
+*.exe
+*.exe~
+*.dll
+*.so
+*.dylib
+*.test
+*.out
+
+# Dependency directories
+vendor/
+
+# Go workspace file
+go.work
+go.work.sum
+
+.env
+`},
+	{"Node", `node_modules/
+dist/
+build/
+coverage/
+.npm
+.eslintcache
+*.tsbuildinfo
+
+# Logs
+logs
+*.log
+npm-debug.log*
+yarn-debug.log*
+yarn-error.log*
+pnpm-debug.log*
+
+.env
+.env.local
+`},
+	{"Python", `__pycache__/
+*.py[cod]
+*.so
+
+# Packaging
+build/
+dist/
+*.egg-info/
+.eggs/
+
+# Environments
+.venv/
+venv/
+env/
+.env
+
+# Tests and tooling
+.pytest_cache/
+.coverage
+htmlcov/
+.mypy_cache/
+.ruff_cache/
+.ipynb_checkpoints
+`},
+	{"Rust", `/target/
+**/*.rs.bk
+*.pdb
+`},
+	{"Java", `*.class
+*.jar
+*.war
+*.ear
+*.log
+hs_err_pid*
+
+# Build tools
+target/
+build/
+.gradle/
+out/
+`},
+	{"macOS", `.DS_Store
+.AppleDouble
+.LSOverride
+._*
+.Spotlight-V100
+.Trashes
+`},
+}
+
+// gitignorePath returns where the repo's top-level .gitignore lives
+func gitignorePath() string {
+	if root, err := git.RepoRoot(); err == nil {
+		return filepath.Join(root, ".gitignore")
+	}
+	return ".gitignore"
+}
+
+// hasGitignore reports whether the repo already has a top-level .gitignore
+func hasGitignore() bool {
+	_, err := os.Stat(gitignorePath())
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// gitignoreOptions lists the bundled templates, led by "None"
+func gitignoreOptions() []huh.Option[string] {
+	options := []huh.Option[string]{huh.NewOption("None", "")}
+	for _, t := range gitignoreTemplates {
+		options = append(options, huh.NewOption(t.name, t.name))
+	}
+	return options
+}
+
+// writeGitignore writes the named template, never replacing an existing file
+func writeGitignore(name string) error {
+	for _, t := range gitignoreTemplates {
+		if !strings.EqualFold(t.name, name) {
+			continue
+		}
+		f, err := os.OpenFile(gitignorePath(), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return err
+		}
+		if _, err := f.WriteString(t.content); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	return nil
+}
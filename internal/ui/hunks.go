@@ -0,0 +1,262 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+type hunkState int
+
+const (
+	hunkStateLoading hunkState = iota
+	hunkStateReview
+	hunkStateSummary
+	hunkStateApplying
+	hunkStateError
+)
+
+// HunkModel walks through unstaged hunks one at a time, like git add -p
+type HunkModel struct {
+	state    hunkState
+	spinner  spinner.Model
+	viewport viewport.Model
+	hunks    []git.Hunk
+	accepted []bool
+	index    int
+	err      error
+}
+
+// NewHunkModel creates a new hunk staging view
+func NewHunkModel(width, height int) *HunkModel {
+	s := newSpinner()
+
+	return &HunkModel{
+		state:    hunkStateLoading,
+		spinner:  s,
+		viewport: viewport.New(width, max(height-diffChromeHeight-2, 3)),
+	}
+}
+
+func (m *HunkModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadHunks,
+	)
+}
+
+func (m *HunkModel) loadHunks() tea.Msg {
+	diff, err := git.GetUnstagedDiff()
+	if err != nil {
+		return hunkErrorMsg{err}
+	}
+	return hunksLoadedMsg{git.ParseHunks(diff)}
+}
+
+// apply stages the accepted hunks in one patch
+func (m *HunkModel) apply() tea.Msg {
+	var picked []git.Hunk
+	for i, h := range m.hunks {
+		if m.accepted[i] {
+			picked = append(picked, h)
+		}
+	}
+	if len(picked) == 0 {
+		return ReturnToMenuMsg{Message: "No hunks staged", Type: "info"}
+	}
+	if err := git.ApplyCached(git.HunkPatch(picked)); err != nil {
+		return hunkErrorMsg{fmt.Errorf("failed to stage hunks: %w", err)}
+	}
+	return ReturnToMenuMsg{Message: fmt.Sprintf("Staged %d of %d hunk(s)", len(picked), len(m.hunks)), Type: "success"}
+}
+
+type hunksLoadedMsg struct{ hunks []git.Hunk }
+type hunkErrorMsg struct{ err error }
+
+// decide records the choice for the current hunk and moves on, to the
+// summary once past the last one
+func (m *HunkModel) decide(accept bool) (tea.Model, tea.Cmd) {
+	m.accepted[m.index] = accept
+	if m.index == len(m.hunks)-1 {
+		m.state = hunkStateSummary
+		return m, nil
+	}
+	m.index++
+	m.showHunk()
+	return m, nil
+}
+
+// showHunk puts the current hunk in the viewport with added and removed lines colored
+func (m *HunkModel) showHunk() {
+	added := lipgloss.NewStyle().Foreground(styles.Green)
+	removed := lipgloss.NewStyle().Foreground(styles.Red)
+	header := lipgloss.NewStyle().Foreground(styles.Cyan)
+	context := lipgloss.NewStyle().Foreground(styles.TextSecondary)
+
+	lines := m.hunks[m.index].Lines
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			out[i] = header.Render(line)
+		case strings.HasPrefix(line, "+"):
+			out[i] = added.Render(line)
+		case strings.HasPrefix(line, "-"):
+			out[i] = removed.Render(line)
+		default:
+			out[i] = context.Render(line)
+		}
+	}
+	m.viewport.SetContent(strings.Join(out, "\n"))
+	m.viewport.GotoTop()
+}
+
+// renderSummary lists every hunk with the decision made for it
+func (m *HunkModel) renderSummary() string {
+	muted := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	var lines []string
+	count := 0
+	for i, h := range m.hunks {
+		at := ""
+		if len(h.Lines) > 0 {
+			at = " " + muted.Render(truncate(h.Lines[0], max(m.viewport.Width-len(h.File)-6, 10)))
+		}
+		if m.accepted[i] {
+			count++
+			lines = append(lines, styles.SuccessStyle.Render(styles.Icons.Check)+" "+h.File+at)
+		} else {
+			lines = append(lines, muted.Render("- "+h.File)+at)
+		}
+	}
+
+	title := fmt.Sprintf("Stage %d of %d hunk(s)?", count, len(m.hunks))
+	return lipgloss.NewStyle().Bold(true).Render(title) + "\n\n" + strings.Join(lines, "\n")
+}
+
+func (m *HunkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			if m.state == hunkStateApplying {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		}
+
+		if m.state == hunkStateReview {
+			switch msg.String() {
+			case "y":
+				return m.decide(true)
+			case "n":
+				return m.decide(false)
+			case "p", "left":
+				if m.index > 0 {
+					m.index--
+					m.showHunk()
+				}
+				return m, nil
+			case "s":
+				// Stage what was accepted so far and skip the rest
+				m.state = hunkStateApplying
+				return m, tea.Batch(m.spinner.Tick, m.apply)
+			}
+		}
+
+		if m.state == hunkStateSummary {
+			switch msg.String() {
+			case "enter", "y":
+				m.state = hunkStateApplying
+				return m, tea.Batch(m.spinner.Tick, m.apply)
+			case "p", "left":
+				// Back to the last hunk to change its decision
+				m.state = hunkStateReview
+				m.showHunk()
+				return m, nil
+			}
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-diffChromeHeight-2, 3)
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case hunksLoadedMsg:
+		if len(msg.hunks) == 0 {
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "No unstaged changes to pick from", Type: "info"}
+			}
+		}
+		m.hunks = msg.hunks
+		m.accepted = make([]bool, len(msg.hunks))
+		m.index = 0
+		m.state = hunkStateReview
+		m.showHunk()
+		return m, nil
+
+	case hunkErrorMsg:
+		m.state = hunkStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m *HunkModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Diff + " Stage Hunks"))
+	if m.state == hunkStateReview {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.TextMuted).Render(
+			fmt.Sprintf(" (%d/%d) %s", m.index+1, len(m.hunks), m.hunks[m.index].File)))
+	}
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case hunkStateLoading:
+		b.WriteString(m.spinner.View() + " Loading unstaged changes...")
+
+	case hunkStateReview:
+		b.WriteString(m.viewport.View())
+		b.WriteString("\n\n")
+		if m.accepted[m.index] {
+			b.WriteString(styles.RenderSuccess("Marked for staging"))
+		}
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("y: stage • n: skip • p: previous • s: stage chosen and finish • esc: cancel"))
+
+	case hunkStateSummary:
+		b.WriteString(m.renderSummary())
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("enter: stage these • p: previous • esc: cancel"))
+
+	case hunkStateApplying:
+		b.WriteString(m.spinner.View() + " Staging hunks...")
+
+	case hunkStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
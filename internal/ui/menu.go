@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,18 +26,33 @@ type Action int
 const (
 	ActionNone Action = iota
 	ActionAdd
+	ActionStageHunks
 	ActionCommit
+	ActionQuickCommit
 	ActionAICommit
+	ActionDiff
 	ActionPush
 	ActionPull
 	ActionReset
 	ActionRollback
+	ActionDiscard
 	ActionRelease
+	ActionTags
 	ActionPublish
 	ActionOpen
+	ActionCloneURLs
+	ActionRemotes
 	ActionLazygit
 	ActionBranches
+	ActionPullRequests
+	ActionPRDescription
+	ActionProjects
+	ActionStats
+	ActionConfig
 	ActionQuit
+	ActionClone
+	ActionConflicts
+	ActionInit
 )
 
 // menuItem implements list.Item
@@ -44,6 +62,7 @@ type menuItem struct {
 	desc     string
 	shortcut string
 	action   Action
+	disabled string // why the action can't run right now, "" when it can
 }
 
 func (i menuItem) Title() string       { return i.icon + "  " + i.title }
@@ -67,7 +86,18 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	var line string
 	isSelected := index == m.Index()
 
-	if isSelected {
+	if i.disabled != "" {
+		// Disabled style: everything muted, with the reason when selected
+		muted := lipgloss.NewStyle().Foreground(styles.TextMuted)
+		prefix := "     "
+		if isSelected {
+			prefix = "  " + styles.Icons.Arrow + " "
+		}
+		line = muted.Render(prefix + i.icon + " " + i.title + " [" + i.shortcut + "]")
+		if isSelected {
+			line += muted.Italic(true).Render("  " + i.disabled)
+		}
+	} else if isSelected {
 		// Selected style: arrow + icon + title with pink color
 		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
 		icon := lipgloss.NewStyle().Foreground(styles.Purple).Render(i.icon)
@@ -92,6 +122,7 @@ type Model struct {
 	items    []menuItem
 	cfg      *config.Config
 	status   *git.Status
+	head     *git.CommitInfo // last commit, nil before the first one
 	spinner  spinner.Model
 	loading  bool
 	message  string
@@ -99,33 +130,155 @@ type Model struct {
 	width    int
 	height   int
 	quitting bool
+	showHelp bool // full keybinding overlay
+	log      []logEntry
 
 	// Sub-models
 	subModel  tea.Model
 	inSubView bool
 }
 
-// NewModel creates a new menu model
-func NewModel(cfg *config.Config) Model {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = styles.SpinnerStyle
-
-	items := []menuItem{
+// defaultMenuItems returns the menu entries with their built-in shortcuts
+func defaultMenuItems() []menuItem {
+	return []menuItem{
 		{icon: styles.Icons.Add, title: "Stage All", desc: "git add .", shortcut: "a", action: ActionAdd},
+		{icon: styles.Icons.Diff, title: "Stage Hunks", desc: "Pick hunks to stage (git add -p)", shortcut: "h", action: ActionStageHunks},
 		{icon: styles.Icons.Commit, title: "Commit", desc: "Commit with message", shortcut: "c", action: ActionCommit},
+		{icon: styles.Icons.Commit, title: "Quick Commit", desc: "Stage everything and commit in one step", shortcut: "C", action: ActionQuickCommit},
 		{icon: styles.Icons.AI, title: "AI Commit", desc: "Generate commit message with AI", shortcut: "i", action: ActionAICommit},
+		{icon: styles.Icons.Diff, title: "Diff", desc: "View staged or full diff", shortcut: "d", action: ActionDiff},
 		{icon: styles.Icons.Push, title: "Push", desc: "Push to remote", shortcut: "p", action: ActionPush},
 		{icon: styles.Icons.Pull, title: "Pull", desc: "Pull from remote", shortcut: "l", action: ActionPull},
 		{icon: styles.Icons.Reset, title: "Reset", desc: "Reset changes (hard)", shortcut: "r", action: ActionReset},
 		{icon: styles.Icons.Reset, title: "Rollback", desc: "Undo last commit (reset HEAD^)", shortcut: "R", action: ActionRollback},
+		{icon: styles.Icons.Trash, title: "Discard Untracked", desc: "Remove untracked files (git clean)", shortcut: "u", action: ActionDiscard},
 		{icon: styles.Icons.Star, title: "Release", desc: "Create & push tag", shortcut: "e", action: ActionRelease},
+		{icon: styles.Icons.Star, title: "Tags", desc: "List and delete tags", shortcut: "t", action: ActionTags},
 		{icon: styles.Icons.Publish, title: "Publish", desc: "Publish to GitHub", shortcut: "P", action: ActionPublish},
 		{icon: styles.Icons.Open, title: "Open Repo", desc: "Open repo in browser", shortcut: "o", action: ActionOpen},
+		{icon: styles.Icons.Git, title: "Clone URLs", desc: "Show and copy SSH/HTTPS clone URLs", shortcut: "y", action: ActionCloneURLs},
+		{icon: styles.Icons.Git, title: "Remotes", desc: "Add, edit or remove remotes", shortcut: "O", action: ActionRemotes},
 		{icon: styles.Icons.Lazygit, title: "Lazygit", desc: "Open lazygit", shortcut: "g", action: ActionLazygit},
-		{icon: styles.Icons.Branch, title: "Branches", desc: "View branches", shortcut: "b", action: ActionBranches},
+		{icon: styles.Icons.Branch, title: "Branches", desc: "View branches and diff against them", shortcut: "b", action: ActionBranches},
+		{icon: styles.Icons.Branch, title: "Pull Requests", desc: "Check out an open PR for review (gh)", shortcut: "v", action: ActionPullRequests},
+		{icon: styles.Icons.AI, title: "PR Description", desc: "Draft a PR body from branch commits with AI", shortcut: "m", action: ActionPRDescription},
+		{icon: styles.Icons.Folder, title: "Projects", desc: "Switch to another repository", shortcut: "w", action: ActionProjects},
+		{icon: styles.Icons.Lightning, title: "Stats", desc: "Commit heatmap and streak", shortcut: "s", action: ActionStats},
+		{icon: styles.Icons.Config, title: "Config", desc: "Edit gitty settings", shortcut: ",", action: ActionConfig},
 		{icon: styles.Icons.Quit, title: "Quit", desc: "Exit gitty", shortcut: "q", action: ActionQuit},
 	}
+}
+
+// cloneMenuItem is the entry offered outside a repository
+func cloneMenuItem() menuItem {
+	return menuItem{icon: styles.Icons.Git, title: "Clone", desc: "Clone a repository and switch to it", shortcut: "n", action: ActionClone}
+}
+
+// initMenuItem is the entry offered outside a repository
+func initMenuItem() menuItem {
+	return menuItem{icon: styles.Icons.Add, title: "Init", desc: "Create a repository in this directory", shortcut: "I", action: ActionInit}
+}
+
+// outsideRepoActions are the only entries that make sense without a repository
+var outsideRepoActions = map[Action]bool{
+	ActionInit:     true,
+	ActionClone:    true,
+	ActionPublish:  true, // initializes one first
+	ActionProjects: true,
+	ActionConfig:   true,
+	ActionQuit:     true,
+}
+
+// conflictsMenuItem is the entry offered while files are unmerged
+func conflictsMenuItem() menuItem {
+	return menuItem{icon: styles.Icons.Warning, title: "Conflicts", desc: "Mark conflicted files resolved or abort", shortcut: "x", action: ActionConflicts}
+}
+
+// actionNames are the names menu actions go by in ui.keybindings
+var actionNames = map[Action]string{
+	ActionAdd:           "stage_all",
+	ActionStageHunks:    "stage_hunks",
+	ActionCommit:        "commit",
+	ActionQuickCommit:   "quick_commit",
+	ActionAICommit:      "ai_commit",
+	ActionDiff:          "diff",
+	ActionPush:          "push",
+	ActionPull:          "pull",
+	ActionReset:         "reset",
+	ActionRollback:      "rollback",
+	ActionDiscard:       "discard",
+	ActionRelease:       "release",
+	ActionTags:          "tags",
+	ActionPublish:       "publish",
+	ActionOpen:          "open",
+	ActionCloneURLs:     "clone_urls",
+	ActionRemotes:       "remotes",
+	ActionLazygit:       "lazygit",
+	ActionBranches:      "branches",
+	ActionPullRequests:  "pull_requests",
+	ActionPRDescription: "pr_description",
+	ActionProjects:      "projects",
+	ActionStats:         "stats",
+	ActionConfig:        "config",
+	ActionQuit:          "quit",
+	ActionClone:         "clone",
+	ActionConflicts:     "conflicts",
+	ActionInit:          "init",
+}
+
+// reservedKeys are handled by the menu itself and cannot be rebound
+var reservedKeys = []string{"?", "/", "L", "f5", "ctrl+r", "ctrl+c", "enter", " ", "up", "down", "j", "k"}
+
+// applyKeybindings replaces default shortcuts with the keys from ui.keybindings
+func applyKeybindings(items []menuItem, bindings map[string]string) []menuItem {
+	for i, item := range items {
+		if key, ok := bindings[actionNames[item.action]]; ok && key != "" {
+			items[i].shortcut = key
+		}
+	}
+	return items
+}
+
+// KeybindingWarnings reports unknown action names, reserved keys and keys
+// bound to more than one action in ui.keybindings
+func KeybindingWarnings(cfg *config.Config) []string {
+	known := make(map[string]bool, len(actionNames))
+	for _, name := range actionNames {
+		known[name] = true
+	}
+
+	var warnings []string
+	for name, key := range cfg.UI.Keybindings {
+		if !known[name] {
+			warnings = append(warnings, fmt.Sprintf("ui.keybindings: unknown action %q", name))
+		}
+		for _, reserved := range reservedKeys {
+			if key == reserved {
+				warnings = append(warnings, fmt.Sprintf("ui.keybindings: %q is reserved and cannot be bound to %s", key, name))
+			}
+		}
+	}
+
+	items := applyKeybindings(append(defaultMenuItems(), cloneMenuItem(), initMenuItem(), conflictsMenuItem()), cfg.UI.Keybindings)
+	owner := make(map[string]string)
+	for _, item := range items {
+		name := actionNames[item.action]
+		if other, ok := owner[item.shortcut]; ok {
+			warnings = append(warnings, fmt.Sprintf("ui.keybindings: %q is bound to both %s and %s", item.shortcut, other, name))
+			continue
+		}
+		owner[item.shortcut] = name
+	}
+
+	sort.Strings(warnings)
+	return warnings
+}
+
+// NewModel creates a new menu model
+func NewModel(cfg *config.Config) Model {
+	s := newSpinner()
+
+	items := applyKeybindings(defaultMenuItems(), cfg.UI.Keybindings)
 
 	// Convert to list.Item slice
 	listItems := make([]list.Item, len(items))
@@ -135,15 +288,16 @@ func NewModel(cfg *config.Config) Model {
 
 	// Create list with custom delegate
 	delegate := itemDelegate{}
-	l := list.New(listItems, delegate, 20, len(items))
+	l := list.New(listItems, delegate, 80, len(items))
 	l.SetShowTitle(false)
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	// The filter is drawn in the status area so the rows don't move
+	l.SetShowFilter(false)
 	l.SetShowHelp(false)
 	l.SetShowPagination(false)
 	l.DisableQuitKeybindings()
 
-	return Model{
+	m := Model{
 		list:    l,
 		items:   items,
 		cfg:     cfg,
@@ -151,14 +305,211 @@ func NewModel(cfg *config.Config) Model {
 		width:   80,
 		height:  24,
 	}
+	return m.resizeList()
+}
+
+// showItem puts a contextual entry, like Clone outside a repository, at the
+// top of the menu and takes it away again once it no longer applies
+func (m Model) showItem(item menuItem, show bool) Model {
+	index := -1
+	for i, existing := range m.items {
+		if existing.action == item.action {
+			index = i
+			break
+		}
+	}
+	if show == (index >= 0) {
+		return m
+	}
+
+	if show {
+		item = applyKeybindings([]menuItem{item}, m.cfg.UI.Keybindings)[0]
+		m.items = append([]menuItem{item}, m.items...)
+	} else {
+		m.items = append(m.items[:index:index], m.items[index+1:]...)
+	}
+	return m.updateListItems()
+}
+
+// reloadConfig rebuilds what NewModel took from the config, after it was
+// saved or reloaded, e.g. a changed keybinding or icon set
+func (m Model) reloadConfig() (Model, tea.Cmd) {
+	m.items = applyKeybindings(defaultMenuItems(), m.cfg.UI.Keybindings)
+	m = m.showItem(cloneMenuItem(), m.status != nil && !m.status.IsRepo)
+	m = m.showItem(initMenuItem(), m.status != nil && !m.status.IsRepo)
+	m = m.showItem(conflictsMenuItem(), m.status != nil && m.status.HasConflicts)
+	// The old spinner's ticks stop at its own ID
+	m.spinner = newSpinner()
+	return m.updateListItems(), m.spinner.Tick
+}
+
+// visibleItems are the entries that apply, all but a few outside a repository
+func (m Model) visibleItems() []menuItem {
+	if m.status == nil || m.status.IsRepo {
+		return m.items
+	}
+	var items []menuItem
+	for _, item := range m.items {
+		if outsideRepoActions[item.action] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// unavailable tells why an action would fail with the cached status, or ""
+// when it can run
+func (m Model) unavailable(action Action) string {
+	s := m.status
+	if s == nil || !s.IsRepo {
+		return ""
+	}
+	changed := s.HasStaged || s.HasUnstaged || s.HasUntracked
+
+	switch action {
+	case ActionAdd:
+		if !s.HasUnstaged && !s.HasUntracked {
+			return "nothing to stage"
+		}
+	case ActionStageHunks:
+		if !s.HasUnstaged {
+			return "no unstaged changes"
+		}
+	case ActionCommit, ActionAICommit:
+		if !s.HasStaged {
+			return "nothing staged"
+		}
+	case ActionQuickCommit, ActionDiff:
+		if !changed {
+			return "no changes"
+		}
+	case ActionReset:
+		// A conflicted merge or cherry-pick is what reset is often needed for
+		if !s.HasStaged && !s.HasUnstaged && !s.HasConflicts && s.Operation == "" {
+			return "no changes to reset"
+		}
+	case ActionDiscard:
+		if !s.HasUntracked {
+			return "no untracked files"
+		}
+	case ActionRollback, ActionRelease:
+		if m.head == nil {
+			return "no commits yet"
+		}
+	case ActionPush, ActionPull, ActionOpen, ActionCloneURLs, ActionPullRequests:
+		if !s.HasRemotes {
+			return "no remote"
+		}
+	}
+	return ""
+}
+
+// updateListItems shows the visible entries, e.g. after entering a
+// repository, and which of them can't run right now
+func (m Model) updateListItems() Model {
+	items := append([]menuItem(nil), m.visibleItems()...)
+	for i := range items {
+		items[i].disabled = m.unavailable(items[i].action)
+	}
+
+	if len(items) == len(m.list.Items()) {
+		same := true
+		for i, item := range m.list.Items() {
+			if item.(menuItem) != items[i] {
+				same = false
+				break
+			}
+		}
+		if same {
+			return m
+		}
+	}
+
+	// Keep the cursor on the same entry when only the disabled ones changed
+	var selected Action
+	if item, ok := m.list.SelectedItem().(menuItem); ok {
+		selected = item.action
+	}
+	listItems := make([]list.Item, len(items))
+	index := 0
+	for i, item := range items {
+		listItems[i] = item
+		if item.action == selected {
+			index = i
+		}
+	}
+	m.list.SetItems(listItems)
+	m.list.Select(index)
+	return m.resizeList()
+}
+
+// renderLanding explains the short menu shown outside a repository
+func (m Model) renderLanding() string {
+	if m.status == nil || m.status.IsRepo {
+		return ""
+	}
+	dir, _ := os.Getwd()
+	return lipgloss.NewStyle().Width(m.width).Render(
+		styles.WarningStyle.Render(dir+" is not a git repository")+"\n"+
+			lipgloss.NewStyle().Foreground(styles.TextMuted).Render("Initialize one here, clone one, publish this folder to GitHub or switch to a project."),
+	) + "\n\n"
+}
+
+// wheelKey turns mouse wheel movement into the matching arrow key
+func wheelKey(msg tea.MouseMsg) (tea.KeyMsg, bool) {
+	if msg.Action != tea.MouseActionPress {
+		return tea.KeyMsg{}, false
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return tea.KeyMsg{Type: tea.KeyUp}, true
+	case tea.MouseButtonWheelDown:
+		return tea.KeyMsg{Type: tea.KeyDown}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// itemAt returns the index of the menu item drawn on screen row y
+func (m Model) itemAt(y int) (int, bool) {
+	// The list starts below the header, divider and landing text
+	row := y - 2 - lipgloss.Height(m.renderLanding()) + 1
+	if row < 0 || row >= m.list.Paginator.PerPage {
+		return 0, false
+	}
+	index := m.list.Paginator.Page*m.list.Paginator.PerPage + row
+	return index, index < len(m.list.VisibleItems())
+}
+
+// resizeList fits the menu to the terminal, paging it when not every item fits
+func (m Model) resizeList() Model {
+	// Header, divider, the blank lines around the status line and the help
+	reserved := 5 + lipgloss.Height(m.renderHelp()) + lipgloss.Height(m.renderLanding()) - 1
+	height := len(m.list.Items())
+	if room := max(m.height-reserved, 3); height > room {
+		height = room
+	}
+	m.list.SetShowPagination(height < len(m.list.Items()))
+	m.list.SetSize(m.width, height)
+	return m
+}
+
+// WithProjectPicker returns the model with the project picker already open
+func (m Model) WithProjectPicker() Model {
+	m.inSubView = true
+	m.subModel = NewProjectsModel(m.cfg, m.width, m.height)
+	return m
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.spinner.Tick,
 		m.refreshStatus,
-	)
+	}
+	if m.inSubView && m.subModel != nil {
+		cmds = append(cmds, m.subModel.Init())
+	}
+	return tea.Batch(cmds...)
 }
 
 // refreshStatus fetches git status
@@ -167,11 +518,18 @@ func (m Model) refreshStatus() tea.Msg {
 	if err != nil {
 		return statusMsg{err: err}
 	}
-	return statusMsg{status: status}
+	msg := statusMsg{status: status}
+	if status.IsRepo {
+		if head, err := git.HeadCommit(); err == nil {
+			msg.head = &head
+		}
+	}
+	return msg
 }
 
 type statusMsg struct {
 	status *git.Status
+	head   *git.CommitInfo
 	err    error
 }
 
@@ -184,35 +542,112 @@ type clearMsgMsg struct{}
 
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Track the terminal size even while a sub-view is open
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = size.Width
+		m.height = size.Height
+		m = m.resizeList()
+	}
+
+	if _, ok := msg.(confirmedQuitMsg); ok {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
 	// Handle sub-view updates
 	if m.inSubView && m.subModel != nil {
+		// Sub-views only know keys, so the wheel scrolls them like the arrows
+		if mouse, ok := msg.(tea.MouseMsg); ok {
+			key, ok := wheelKey(mouse)
+			if !ok {
+				return m, nil
+			}
+			msg = key
+		}
+
 		var cmd tea.Cmd
 		m.subModel, cmd = m.subModel.Update(msg)
 
 		// Check if sub-view wants to return
 		if returnMsg, ok := msg.(ReturnToMenuMsg); ok {
+			var reload tea.Cmd
+			if _, ok := m.subModel.(*ConfigModel); ok {
+				m, reload = m.reloadConfig()
+			}
 			m.inSubView = false
 			m.subModel = nil
 			if returnMsg.Message != "" {
 				m.message = returnMsg.Message
 				m.msgType = returnMsg.Type
+				m = m.logMessage(returnMsg.Message, returnMsg.Type)
 			}
-			return m, tea.Batch(m.refreshStatus, clearMessageAfter())
+			return m, tea.Batch(m.refreshStatus, clearMessageAfter(), reload)
 		}
 
 		return m, cmd
 	}
 
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		if m.loading || m.showHelp {
+			return m, nil
+		}
+		if key, ok := wheelKey(msg); ok {
+			var cmd tea.Cmd
+			m.list, cmd = m.list.Update(key)
+			return m, cmd
+		}
+		if msg.Action == tea.MouseActionRelease && msg.Button == tea.MouseButtonLeft {
+			if index, ok := m.itemAt(msg.Y); ok {
+				m.list.Select(index)
+				if item, ok := m.list.SelectedItem().(menuItem); ok {
+					return m.executeAction(item.action)
+				}
+			}
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.loading {
+			// Stop the git command in flight; its action reports the cancellation
+			if msg.String() == "esc" || msg.String() == "ctrl+c" {
+				git.CancelRunning()
+			}
+			return m, nil
+		}
+
+		// Let the list handle typing a filter and clearing it with esc
+		if msg.String() != "ctrl+c" && (m.list.SettingFilter() || (msg.String() == "esc" && m.list.IsFiltered())) {
+			break
+		}
+
+		// The help overlay swallows keys until dismissed
+		if m.showHelp {
+			if msg.String() == "?" || msg.String() == "esc" || msg.String() == "q" {
+				m.showHelp = false
+			}
 			return m, nil
 		}
 
 		switch msg.String() {
-		case "q", "ctrl+c":
-			m.quitting = true
-			return m, tea.Quit
+		case "?":
+			m.showHelp = true
+			return m, nil
+
+		case "ctrl+c":
+			return m.quit()
+
+		case "L":
+			m.inSubView = true
+			m.subModel = NewMessageLogModel(m.log, m.width, m.height)
+			return m, m.subModel.Init()
+
+		case "f5", "ctrl+r":
+			// R is taken by Rollback. Changes made outside gitty may be newer
+			// than the cached status.
+			git.InvalidateStatusCache()
+			m.loading = true
+			return m, m.refreshStatus
 
 		case "enter", " ":
 			if item, ok := m.list.SelectedItem().(menuItem); ok {
@@ -221,7 +656,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		default:
 			// Handle shortcut keys
-			for _, item := range m.items {
+			for _, item := range m.visibleItems() {
 				if msg.String() == item.shortcut {
 					return m.executeAction(item.action)
 				}
@@ -239,16 +674,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case statusMsg:
 		m.status = msg.status
+		m.head = msg.head
 		m.loading = false
+		m = m.showItem(cloneMenuItem(), m.status != nil && !m.status.IsRepo)
+		m = m.showItem(initMenuItem(), m.status != nil && !m.status.IsRepo)
+		m = m.showItem(conflictsMenuItem(), m.status != nil && m.status.HasConflicts)
+		m = m.updateListItems()
+
+	case pushPickMsg:
+		m.loading = false
+		m.inSubView = true
+		m.subModel = NewPushModel(m.cfg, msg.remotes)
+		return m, m.subModel.Init()
+
+	case pushNoUpstreamMsg:
+		m.loading = false
+		m.inSubView = true
+		m.subModel = NewSetUpstreamModel(m.cfg)
+		return m, m.subModel.Init()
+
+	case pushRejectedMsg:
+		m.loading = false
+		m.inSubView = true
+		m.subModel = NewDivergedPushModel(m.cfg, 0, true)
+		return m, m.subModel.Init()
 
 	case actionCompleteMsg:
 		m.loading = false
-		m.message = msg.message
+		msgType := "error"
 		if msg.success {
-			m.msgType = "success"
-		} else {
-			m.msgType = "error"
+			msgType = "success"
+		}
+		m = m.logMessage(msg.message, msgType)
+
+		// Results that don't fit the status area, e.g. push errors, get a scrollable view
+		if lipgloss.Height(lipgloss.NewStyle().Width(m.width).Render(msg.message)) > m.statusAreaHeight() {
+			m.inSubView = true
+			m.subModel = NewOutputModel(msg.success, msg.message, m.width, m.height)
+			return m, m.subModel.Init()
 		}
+
+		m.message = msg.message
+		m.msgType = msgType
 		return m, tea.Batch(m.refreshStatus, clearMessageAfter())
 
 	case clearMsgMsg:
@@ -268,11 +735,27 @@ func clearMessageAfter() tea.Cmd {
 	})
 }
 
+// quit exits, first asking when ui.confirm_quit is set and the tree is dirty
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	if m.cfg.UI.ConfirmQuit && isDirty(m.status) {
+		m.inSubView = true
+		m.subModel = NewQuitModel(m.cfg, m.status)
+		return m, m.subModel.Init()
+	}
+	m.quitting = true
+	return m, tea.Quit
+}
+
 func (m Model) executeAction(action Action) (tea.Model, tea.Cmd) {
+	if reason := m.unavailable(action); reason != "" {
+		m.message = fmt.Sprintf("Not available: %s", reason)
+		m.msgType = "info"
+		return m, clearMessageAfter()
+	}
+
 	switch action {
 	case ActionQuit:
-		m.quitting = true
-		return m, tea.Quit
+		return m.quit()
 
 	case ActionAdd:
 		m.loading = true
@@ -283,10 +766,31 @@ func (m Model) executeAction(action Action) (tea.Model, tea.Cmd) {
 			return actionCompleteMsg{true, "All files staged"}
 		}
 
+	case ActionStageHunks:
+		m.inSubView = true
+		m.subModel = NewHunkModel(m.width, m.height)
+		return m, m.subModel.Init()
+
 	case ActionPush:
+		// Warn before a push that would be rejected
+		if m.status != nil && m.status.Behind > 0 {
+			m.inSubView = true
+			m.subModel = NewDivergedPushModel(m.cfg, m.status.Behind, false)
+			return m, m.subModel.Init()
+		}
 		m.loading = true
 		return m, func() tea.Msg {
+			// Only ask where to push when there is a choice to make
+			if remotes, err := git.ListRemotes(); err == nil && len(remotes) > 1 {
+				return pushPickMsg{remotes}
+			}
 			if err := git.Push(); err != nil {
+				if errors.Is(err, git.ErrNoUpstream) {
+					return pushNoUpstreamMsg{}
+				}
+				if errors.Is(err, git.ErrPushRejected) {
+					return pushRejectedMsg{}
+				}
 				return actionCompleteMsg{false, fmt.Sprintf("Push failed: %v", err)}
 			}
 			return actionCompleteMsg{true, "Pushed to remote"}
@@ -296,6 +800,9 @@ func (m Model) executeAction(action Action) (tea.Model, tea.Cmd) {
 		m.loading = true
 		return m, func() tea.Msg {
 			if err := git.Pull(); err != nil {
+				if errors.Is(err, git.ErrPullConflict) {
+					return actionCompleteMsg{false, "Pull stopped on conflicts, open Conflicts to resolve or abort"}
+				}
 				return actionCompleteMsg{false, fmt.Sprintf("Pull failed: %v", err)}
 			}
 			return actionCompleteMsg{true, "Pulled from remote"}
@@ -303,17 +810,27 @@ func (m Model) executeAction(action Action) (tea.Model, tea.Cmd) {
 
 	case ActionReset:
 		m.inSubView = true
-		m.subModel = NewResetModel()
+		m.subModel = NewResetModel(m.cfg)
 		return m, m.subModel.Init()
 
 	case ActionRollback:
 		m.inSubView = true
-		m.subModel = NewRollbackModel()
+		m.subModel = NewRollbackModel(m.cfg)
+		return m, m.subModel.Init()
+
+	case ActionDiscard:
+		m.inSubView = true
+		m.subModel = NewDiscardModel(m.cfg)
 		return m, m.subModel.Init()
 
 	case ActionRelease:
 		m.inSubView = true
-		m.subModel = NewReleaseModel()
+		m.subModel = NewReleaseModel(m.cfg)
+		return m, m.subModel.Init()
+
+	case ActionTags:
+		m.inSubView = true
+		m.subModel = NewTagsModel(m.cfg, m.width, m.height)
 		return m, m.subModel.Init()
 
 	case ActionCommit:
@@ -321,11 +838,21 @@ func (m Model) executeAction(action Action) (tea.Model, tea.Cmd) {
 		m.subModel = NewCommitModel(m.cfg, false)
 		return m, m.subModel.Init()
 
+	case ActionQuickCommit:
+		m.inSubView = true
+		m.subModel = NewCommitModel(m.cfg, false).WithStageAll()
+		return m, m.subModel.Init()
+
 	case ActionAICommit:
 		m.inSubView = true
 		m.subModel = NewCommitModel(m.cfg, true)
 		return m, m.subModel.Init()
 
+	case ActionDiff:
+		m.inSubView = true
+		m.subModel = NewDiffModel(m.width, m.height)
+		return m, m.subModel.Init()
+
 	case ActionPublish:
 		m.inSubView = true
 		m.subModel = NewPublishModel(m.cfg)
@@ -334,16 +861,46 @@ func (m Model) executeAction(action Action) (tea.Model, tea.Cmd) {
 	case ActionOpen:
 		m.loading = true
 		return m, func() tea.Msg {
-			url, err := git.GetGitHubURL()
+			url, err := git.GetWebURL()
 			if err != nil {
-				return actionCompleteMsg{false, fmt.Sprintf("Not a GitHub repo: %v", err)}
+				return actionCompleteMsg{false, fmt.Sprintf("Cannot open repo: %v", err)}
 			}
-			if err := git.OpenBrowser(url); err != nil {
+			if err := git.OpenBrowser(url, m.cfg.UI.Browser); err != nil {
 				return actionCompleteMsg{false, fmt.Sprintf("Failed to open: %v", err)}
 			}
 			return actionCompleteMsg{true, "Opened in browser"}
 		}
 
+	case ActionInit:
+		m.loading = true
+		return m, func() tea.Msg {
+			if err := git.Init(m.cfg.Git.DefaultBranch); err != nil {
+				return actionCompleteMsg{false, fmt.Sprintf("Init failed: %v", err)}
+			}
+			dir, _ := os.Getwd()
+			return actionCompleteMsg{true, "Initialized a repository in " + dir}
+		}
+
+	case ActionClone:
+		m.inSubView = true
+		m.subModel = NewCloneModel(m.cfg)
+		return m, m.subModel.Init()
+
+	case ActionConflicts:
+		m.inSubView = true
+		m.subModel = NewConflictsModel(m.cfg, m.width, m.height)
+		return m, m.subModel.Init()
+
+	case ActionCloneURLs:
+		m.inSubView = true
+		m.subModel = NewCloneURLsModel()
+		return m, m.subModel.Init()
+
+	case ActionRemotes:
+		m.inSubView = true
+		m.subModel = NewRemotesModel(m.cfg, m.width, m.height)
+		return m, m.subModel.Init()
+
 	case ActionLazygit:
 		c := exec.Command("lazygit")
 		return m, tea.ExecProcess(c, func(err error) tea.Msg {
@@ -354,14 +911,34 @@ func (m Model) executeAction(action Action) (tea.Model, tea.Cmd) {
 		})
 
 	case ActionBranches:
-		m.loading = true
-		return m, func() tea.Msg {
-			branches, err := git.GetBranches()
-			if err != nil {
-				return actionCompleteMsg{false, fmt.Sprintf("Failed to get branches: %v", err)}
-			}
-			return actionCompleteMsg{true, fmt.Sprintf("Branches: %s", strings.Join(branches, ", "))}
-		}
+		m.inSubView = true
+		m.subModel = NewBranchesModel(m.cfg, m.width, m.height)
+		return m, m.subModel.Init()
+
+	case ActionPullRequests:
+		m.inSubView = true
+		m.subModel = NewPullRequestsModel(m.width, m.height)
+		return m, m.subModel.Init()
+
+	case ActionPRDescription:
+		m.inSubView = true
+		m.subModel = NewPRModel(m.cfg, m.width, m.height)
+		return m, m.subModel.Init()
+
+	case ActionProjects:
+		m.inSubView = true
+		m.subModel = NewProjectsModel(m.cfg, m.width, m.height)
+		return m, m.subModel.Init()
+
+	case ActionStats:
+		m.inSubView = true
+		m.subModel = NewStatsModel()
+		return m, m.subModel.Init()
+
+	case ActionConfig:
+		m.inSubView = true
+		m.subModel = NewConfigModel(m.cfg)
+		return m, m.subModel.Init()
 	}
 
 	return m, nil
@@ -378,6 +955,10 @@ func (m Model) View() string {
 		return m.subModel.View()
 	}
 
+	if m.showHelp {
+		return m.renderHelpOverlay()
+	}
+
 	var b strings.Builder
 
 	// Header
@@ -385,6 +966,7 @@ func (m Model) View() string {
 	b.WriteString("\n")
 	b.WriteString(styles.Divider(m.width))
 	b.WriteString("\n")
+	b.WriteString(m.renderLanding())
 
 	// Menu list
 	b.WriteString(m.list.View())
@@ -393,7 +975,13 @@ func (m Model) View() string {
 	b.WriteString("\n\n")
 
 	if m.loading {
-		b.WriteString(fmt.Sprintf("%s Working...", m.spinner.View()))
+		b.WriteString(fmt.Sprintf("%s Working... ", m.spinner.View()))
+		b.WriteString(styles.HelpStyle.Render("esc: cancel"))
+	} else if m.list.SettingFilter() {
+		b.WriteString(m.list.FilterInput.View())
+	} else if m.list.IsFiltered() {
+		b.WriteString(styles.RenderInfo("Filtered by " + m.list.FilterValue()))
+		b.WriteString(" " + styles.HelpStyle.Render("esc: clear"))
 	} else if m.message != "" {
 		switch m.msgType {
 		case "success":
@@ -414,6 +1002,13 @@ func (m Model) View() string {
 	return b.String()
 }
 
+// statusAreaHeight is how many lines are left below the menu for a message
+func (m Model) statusAreaHeight() int {
+	// Header, divider, landing text, the list, blank lines and the help lines
+	used := 2 + lipgloss.Height(m.renderLanding()) - 1 + m.list.Height() + 4 + lipgloss.Height(m.renderHelp())
+	return max(m.height-used, 1)
+}
+
 func (m Model) renderHeader() string {
 	title := lipgloss.NewStyle().
 		Bold(true).
@@ -425,41 +1020,103 @@ func (m Model) renderHeader() string {
 		Foreground(styles.TextMuted).
 		Render(" | ")
 
+	// Badges in display order; on a narrow terminal the lowest priority go first
+	type badge struct {
+		text     string
+		priority int
+	}
+	var badges []badge
+
 	// Branch info (if in a repo)
 	var branchInfo string
 	if m.status != nil && m.status.IsRepo {
-		branch := lipgloss.NewStyle().Foreground(styles.Cyan).Bold(true).Render(m.status.Branch)
+		branchStyle := lipgloss.NewStyle().Foreground(styles.Cyan).Bold(true)
+		if m.status.Detached {
+			branchStyle = branchStyle.Foreground(styles.Yellow)
+		}
+		// Leave some room for the badges next to a long branch name
+		room := max(m.width-lipgloss.Width(title+separator)-20, 12)
+		branchInfo = branchStyle.Render(truncate(m.status.Branch, room))
 
-		var statusParts []string
 		if m.status.HasStaged {
-			statusParts = append(statusParts, styles.SuccessStyle.Render(fmt.Sprintf("+%d", len(m.status.StagedFiles))))
+			badges = append(badges, badge{styles.SuccessStyle.Render(fmt.Sprintf("+%d", len(m.status.StagedFiles))), 4})
 		}
 		if m.status.HasUnstaged {
-			statusParts = append(statusParts, styles.WarningStyle.Render(fmt.Sprintf("~%d", len(m.status.ModifiedFiles))))
+			badges = append(badges, badge{styles.WarningStyle.Render(fmt.Sprintf("~%d", len(m.status.ModifiedFiles))), 3})
 		}
 		if m.status.HasUntracked {
-			statusParts = append(statusParts, styles.InfoStyle.Render(fmt.Sprintf("?%d", len(m.status.UntrackedFiles))))
+			badges = append(badges, badge{styles.InfoStyle.Render(fmt.Sprintf("?%d", len(m.status.UntrackedFiles))), 0})
 		}
 		if m.status.Ahead > 0 {
-			statusParts = append(statusParts, lipgloss.NewStyle().Foreground(styles.Blue).Render(fmt.Sprintf("↑%d", m.status.Ahead)))
+			badges = append(badges, badge{lipgloss.NewStyle().Foreground(styles.Blue).Render(fmt.Sprintf("↑%d", m.status.Ahead)), 1})
 		}
 		if m.status.Behind > 0 {
-			statusParts = append(statusParts, lipgloss.NewStyle().Foreground(styles.Yellow).Render(fmt.Sprintf("↓%d", m.status.Behind)))
+			badges = append(badges, badge{lipgloss.NewStyle().Foreground(styles.Yellow).Render(fmt.Sprintf("↓%d", m.status.Behind)), 2})
 		}
-		if !m.status.HasStaged && !m.status.HasUnstaged && !m.status.HasUntracked {
-			statusParts = append(statusParts, styles.SuccessStyle.Render(styles.Icons.Check))
+		if m.status.HasConflicts {
+			badges = append(badges, badge{styles.ErrorStyle.Render(fmt.Sprintf("%s %d conflict(s)", styles.Icons.Cross, len(m.status.ConflictedFiles))), 5})
 		}
-
-		branchInfo = branch
-		if len(statusParts) > 0 {
-			branchInfo += "  " + strings.Join(statusParts, " ")
+		if !m.status.HasStaged && !m.status.HasUnstaged && !m.status.HasUntracked && !m.status.HasConflicts {
+			badges = append(badges, badge{styles.SuccessStyle.Render(styles.Icons.Check), 0})
 		}
 	} else {
 		branchInfo = styles.WarningStyle.Render(styles.Icons.Warning + " Not a git repo")
 	}
 
-	// Join with pipe separator
-	return title + separator + branchInfo
+	// Make it obvious nothing destructive will run
+	if git.DryRun() {
+		badges = append(badges, badge{styles.WarningStyle.Render("(dry run)"), 6})
+	}
+
+	render := func() string {
+		header := title + separator + branchInfo
+		for i, b := range badges {
+			if i == 0 {
+				header += " "
+			}
+			header += " " + b.text
+		}
+		return header
+	}
+	header := render()
+	for len(badges) > 0 && lipgloss.Width(header) > m.width {
+		lowest := 0
+		for i, b := range badges {
+			if b.priority < badges[lowest].priority {
+				lowest = i
+			}
+		}
+		badges = append(badges[:lowest], badges[lowest+1:]...)
+		header = render()
+	}
+
+	// Last commit, as much of its subject as fits
+	if m.head != nil {
+		hash := m.head.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		room := m.width - lipgloss.Width(header) - lipgloss.Width(separator) - len(hash) - 1
+		if room >= 10 {
+			header += separator +
+				lipgloss.NewStyle().Foreground(styles.Yellow).Render(hash) + " " +
+				lipgloss.NewStyle().Foreground(styles.TextMuted).Render(truncate(m.head.Subject, room))
+		}
+	}
+
+	return header
+}
+
+// truncate shortens s to width cells, ending it with an ellipsis when cut
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
 }
 
 func (m Model) renderHelp() string {
@@ -469,9 +1126,74 @@ func (m Model) renderHelp() string {
 	help := []string{
 		keyStyle.Render("↑↓") + descStyle.Render(" navigate"),
 		keyStyle.Render("enter") + descStyle.Render(" select"),
+		keyStyle.Render("/") + descStyle.Render(" filter"),
+		keyStyle.Render("f5") + descStyle.Render(" refresh"),
+		keyStyle.Render("?") + descStyle.Render(" help"),
 		keyStyle.Render("q") + descStyle.Render(" quit"),
 	}
-	return strings.Join(help, "  ")
+
+	// Wrap between entries rather than inside one
+	var lines []string
+	line := ""
+	for _, h := range help {
+		switch {
+		case line == "":
+			line = h
+		case lipgloss.Width(line+"  "+h) > m.width:
+			lines = append(lines, line)
+			line = h
+		default:
+			line += "  " + h
+		}
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+// subViewKeys lists the keys available inside sub-views, shown in the help overlay
+var subViewKeys = []struct{ view, keys string }{
+	{"Commit", "y confirm • n cancel • e edit • E edit in git.editor • r regenerate/retry • t link issue • v skip hooks"},
+	{"Stage Hunks", "y stage • n skip • p previous • s finish early • enter stage after the summary"},
+	{"Diff", "t staged/full • ↑↓ pgup pgdn scroll"},
+	{"Branches", "enter/d diff • m merge • c cherry-pick • n new branch • / filter"},
+	{"Tags", "d delete • / filter"},
+	{"Clone", "r try again after a failure"},
+	{"Conflicts", "e edit in git.editor • s mark resolved • a abort the merge, rebase or cherry-pick"},
+	{"Clone URLs", "s copy SSH • h copy HTTPS"},
+	{"Remotes", "a add • e edit URL • d remove"},
+	{"Projects", "enter switch • r rescan • / filter"},
+	{"PR Description", "c copy • r regenerate"},
+	{"Publish", "i/w toggle gh flags • c copy URL"},
+}
+
+// renderHelpOverlay lists every menu shortcut and sub-view key in a box
+func (m Model) renderHelpOverlay() string {
+	keyStyle := lipgloss.NewStyle().Foreground(styles.Purple).Bold(true).Width(8)
+	viewStyle := lipgloss.NewStyle().Foreground(styles.Pink).Width(16)
+	descStyle := lipgloss.NewStyle().Foreground(styles.TextSecondary)
+
+	var lines []string
+	lines = append(lines, styles.TitleStyle.Render("Menu"), "")
+	for _, item := range m.visibleItems() {
+		lines = append(lines, keyStyle.Render(item.shortcut)+descStyle.Render(item.title+" - "+item.desc))
+	}
+	lines = append(lines,
+		keyStyle.Render("/")+descStyle.Render("Filter the menu by name"),
+		keyStyle.Render("L")+descStyle.Render("Message log of recent results"),
+		keyStyle.Render("f5")+descStyle.Render("Refresh status"),
+		keyStyle.Render("?")+descStyle.Render("Toggle this help"),
+		"", styles.TitleStyle.Render("Inside views"), "")
+	for _, v := range subViewKeys {
+		lines = append(lines, viewStyle.Render(v.view)+descStyle.Render(v.keys))
+	}
+	lines = append(lines, "", descStyle.Render("esc always goes back"))
+
+	box := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Purple).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return box + "\n" + styles.HelpStyle.Render("?/esc: close")
 }
 
 // ReturnToMenuMsg signals return to main menu
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// maxLogEntries bounds how many action results the message log keeps
+const maxLogEntries = 100
+
+// logEntry is an action result kept after the status line cleared it
+type logEntry struct {
+	at      time.Time
+	message string
+	msgType string // "success", "error", "info"
+}
+
+// logMessage records a result for the message log, dropping the oldest
+func (m Model) logMessage(message, msgType string) Model {
+	if message == "" {
+		return m
+	}
+	m.log = append(m.log, logEntry{time.Now(), message, msgType})
+	if len(m.log) > maxLogEntries {
+		m.log = m.log[len(m.log)-maxLogEntries:]
+	}
+	return m
+}
+
+// MessageLogModel lists recent action results, newest first
+type MessageLogModel struct {
+	entries  []logEntry
+	viewport viewport.Model
+}
+
+// NewMessageLogModel creates a scrollable view of the message log
+func NewMessageLogModel(entries []logEntry, width, height int) *MessageLogModel {
+	m := &MessageLogModel{
+		entries:  entries,
+		viewport: viewport.New(width, max(height-diffChromeHeight, 3)),
+	}
+	m.render()
+	return m
+}
+
+// render lays out the entries for the current width
+func (m *MessageLogModel) render() {
+	timeStyle := lipgloss.NewStyle().Foreground(styles.TextMuted)
+	// Wrapped lines line up under the message, past the time and icon
+	textStyle := lipgloss.NewStyle().Width(max(m.viewport.Width-11, 20))
+
+	var blocks []string
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		e := m.entries[i]
+		var icon string
+		switch e.msgType {
+		case "success":
+			icon = styles.SuccessStyle.Render(styles.Icons.Check)
+		case "error":
+			icon = styles.ErrorStyle.Render(styles.Icons.Cross)
+		default:
+			icon = styles.InfoStyle.Render(styles.Icons.Info)
+		}
+		blocks = append(blocks, lipgloss.JoinHorizontal(lipgloss.Top,
+			timeStyle.Render(e.at.Format("15:04:05"))+" "+icon+" ",
+			textStyle.Render(e.message)))
+	}
+	m.viewport.SetContent(strings.Join(blocks, "\n"))
+}
+
+func (m *MessageLogModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *MessageLogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q", "L":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-diffChromeHeight, 3)
+		m.render()
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m *MessageLogModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Info + " Message Log"))
+	b.WriteString("\n\n")
+
+	if len(m.entries) == 0 {
+		b.WriteString(styles.HelpStyle.Render("Nothing yet, results of actions show up here"))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("esc: back"))
+		return b.String()
+	}
+
+	b.WriteString(m.viewport.View())
+	b.WriteString("\n\n")
+	b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("%d message(s) • ↑↓/pgup/pgdn: scroll • esc: back  %3.f%%", len(m.entries), m.viewport.ScrollPercent()*100)))
+
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// OutputModel shows an action result too long for the status line
+type OutputModel struct {
+	success  bool
+	viewport viewport.Model
+}
+
+// NewOutputModel creates a scrollable view of an action result
+func NewOutputModel(success bool, text string, width, height int) *OutputModel {
+	vp := viewport.New(width, max(height-diffChromeHeight, 3))
+	vp.SetContent(lipgloss.NewStyle().Width(width).Render(text))
+
+	return &OutputModel{
+		success:  success,
+		viewport: vp,
+	}
+}
+
+func (m *OutputModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *OutputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q", "enter":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-diffChromeHeight, 3)
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m *OutputModel) View() string {
+	var b strings.Builder
+
+	// Header
+	if m.success {
+		b.WriteString(styles.SuccessStyle.Render(styles.Icons.Check + " Done"))
+	} else {
+		b.WriteString(styles.ErrorStyle.Render(styles.Icons.Cross + " Failed"))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(m.viewport.View())
+	b.WriteString("\n\n")
+	b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("↑↓/pgup/pgdn: scroll • esc: back  %3.f%%", m.viewport.ScrollPercent()*100)))
+
+	return b.String()
+}
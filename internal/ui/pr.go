@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/ai"
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// prBaseCandidates are tried in order to find what the branch will merge into
+var prBaseCandidates = []string{"origin/HEAD", "origin/main", "origin/master", "main", "master"}
+
+type prState int
+
+const (
+	prStateGenerating prState = iota
+	prStateDone
+	prStateError
+)
+
+// PRModel drafts a pull request description from the branch commits
+type PRModel struct {
+	cfg         *config.Config
+	state       prState
+	spinner     spinner.Model
+	viewport    viewport.Model
+	base        string
+	commits     int
+	description string
+	message     string
+	msgType     string
+	err         error
+}
+
+// NewPRModel creates a new PR description view
+func NewPRModel(cfg *config.Config, width, height int) *PRModel {
+	s := newSpinner()
+
+	return &PRModel{
+		cfg:      cfg,
+		state:    prStateGenerating,
+		spinner:  s,
+		viewport: viewport.New(width, max(height-diffChromeHeight-2, 3)),
+	}
+}
+
+func (m *PRModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.generate(),
+	)
+}
+
+// generate collects the branch commits and asks the AI to describe them
+func (m *PRModel) generate() tea.Cmd {
+	cfg, width := m.cfg, m.viewport.Width
+	return func() tea.Msg {
+		base := ""
+		for _, candidate := range prBaseCandidates {
+			if git.RefExists(candidate) {
+				base = candidate
+				break
+			}
+		}
+		if base == "" {
+			return prErrorMsg{fmt.Errorf("no base branch found (tried %s)", strings.Join(prBaseCandidates, ", "))}
+		}
+
+		commits, err := git.CommitsSince(base)
+		if err != nil {
+			return prErrorMsg{err}
+		}
+		if len(commits) == 0 {
+			return prErrorMsg{fmt.Errorf("no commits ahead of %s", base)}
+		}
+
+		description, err := ai.GeneratePRDescription(commits, cfg)
+		if err != nil {
+			return prErrorMsg{err}
+		}
+		return prGeneratedMsg{base, len(commits), description, renderMarkdown(description, width)}
+	}
+}
+
+// renderMarkdown renders markdown through glamour, falling back to plain text
+func renderMarkdown(md string, width int) string {
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle("dark"),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return md
+	}
+	out, err := r.Render(md)
+	if err != nil {
+		return md
+	}
+	return out
+}
+
+type prGeneratedMsg struct {
+	base        string
+	commits     int
+	description string
+	rendered    string
+}
+
+type prErrorMsg struct{ err error }
+
+func (m *PRModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "c":
+			if m.state == prStateDone {
+				m.copy()
+				return m, nil
+			}
+		case "r":
+			if m.state != prStateGenerating {
+				m.state = prStateGenerating
+				m.message = ""
+				return m, tea.Batch(m.spinner.Tick, m.generate())
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-diffChromeHeight-2, 3)
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case prGeneratedMsg:
+		m.state = prStateDone
+		m.base = msg.base
+		m.commits = msg.commits
+		m.description = msg.description
+		m.viewport.SetContent(msg.rendered)
+		m.viewport.GotoTop()
+		m.copy()
+		return m, nil
+
+	case prErrorMsg:
+		m.state = prStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// copy puts the raw markdown on the clipboard
+func (m *PRModel) copy() {
+	if err := clipboard.WriteAll(m.description); err != nil {
+		m.message = fmt.Sprintf("Could not copy: %v", err)
+		m.msgType = "error"
+		return
+	}
+	m.message = "Copied to clipboard"
+	m.msgType = "success"
+}
+
+func (m *PRModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.AI + " PR Description"))
+	if m.state == prStateDone {
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.TextMuted).Render(fmt.Sprintf(" (%d commits since %s)", m.commits, m.base)))
+	}
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case prStateGenerating:
+		b.WriteString(m.spinner.View() + " Summarizing branch commits with AI...")
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("esc: back"))
+
+	case prStateDone:
+		b.WriteString(m.viewport.View())
+		b.WriteString("\n")
+		switch m.msgType {
+		case "success":
+			b.WriteString(styles.RenderSuccess(m.message))
+		case "error":
+			b.WriteString(styles.RenderError(m.message))
+		}
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("↑↓: scroll • c: copy • r: regenerate • esc: back"))
+
+	case prStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("r: retry • esc: back"))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/projects"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// projectItem implements list.Item
+type projectItem struct {
+	name string
+	path string
+}
+
+func (i projectItem) FilterValue() string { return i.name }
+
+// projectDelegate renders a repo name with its location
+type projectDelegate struct{}
+
+func (d projectDelegate) Height() int                             { return 1 }
+func (d projectDelegate) Spacing() int                            { return 0 }
+func (d projectDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d projectDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(projectItem)
+	if !ok {
+		return
+	}
+
+	path := lipgloss.NewStyle().Foreground(styles.TextMuted).Render("  " + filepath.Dir(i.path))
+
+	var line string
+	if index == m.Index() {
+		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
+		name := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true).Render(i.name)
+		line = arrow + name + path
+	} else {
+		name := lipgloss.NewStyle().Foreground(styles.TextPrimary).Render(i.name)
+		line = "     " + name + path
+	}
+
+	fmt.Fprint(w, line)
+}
+
+type projectsState int
+
+const (
+	projectsStateScanning projectsState = iota
+	projectsStateList
+	projectsStateError
+)
+
+// ProjectsModel picks a repository under the configured projects directory
+type ProjectsModel struct {
+	root      string
+	state     projectsState
+	spinner   spinner.Model
+	list      list.Model
+	scannedAt time.Time
+	err       error
+}
+
+// NewProjectsModel creates a new repository picker
+func NewProjectsModel(cfg *config.Config, width, height int) *ProjectsModel {
+	s := newSpinner()
+
+	l := list.New(nil, projectDelegate{}, width, max(height-4, 5))
+	l.Title = "Projects"
+	l.Styles.Title = styles.TitleStyle
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.DisableQuitKeybindings()
+
+	return &ProjectsModel{
+		root:    projects.ExpandHome(cfg.UI.ProjectsDir),
+		state:   projectsStateScanning,
+		spinner: s,
+		list:    l,
+	}
+}
+
+func (m *ProjectsModel) Init() tea.Cmd {
+	if m.root == "" {
+		m.state = projectsStateError
+		m.err = fmt.Errorf("no projects directory configured, set ui.projects_dir in %s", config.ConfigPath())
+		return nil
+	}
+
+	// Show the cached scan straight away, scanning only when there is none
+	if cache, ok := projects.LoadCache(m.root); ok {
+		return func() tea.Msg { return projectsScannedMsg{cache} }
+	}
+	return tea.Batch(
+		m.spinner.Tick,
+		m.scan,
+	)
+}
+
+func (m *ProjectsModel) scan() tea.Msg {
+	repos, err := projects.Scan(m.root)
+	if err != nil {
+		return projectsErrorMsg{err}
+	}
+
+	cache := &projects.Cache{Root: m.root, ScannedAt: time.Now(), Repos: repos}
+	_ = projects.SaveCache(cache)
+	return projectsScannedMsg{cache}
+}
+
+type projectsScannedMsg struct{ cache *projects.Cache }
+type projectsErrorMsg struct{ err error }
+
+func (m *ProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, max(msg.Height-4, 5))
+
+	case tea.KeyMsg:
+		// Let the list handle typing a filter and clearing it with esc
+		if m.list.SettingFilter() || (msg.String() == "esc" && m.list.IsFiltered()) {
+			break
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "r":
+			if m.state != projectsStateScanning && m.root != "" {
+				m.state = projectsStateScanning
+				return m, tea.Batch(m.spinner.Tick, m.scan)
+			}
+		case "enter":
+			if item, ok := m.list.SelectedItem().(projectItem); ok {
+				return m, func() tea.Msg {
+					if err := os.Chdir(item.path); err != nil {
+						return ReturnToMenuMsg{Message: fmt.Sprintf("Failed to switch: %v", err), Type: "error"}
+					}
+					return ReturnToMenuMsg{Message: fmt.Sprintf("Switched to %s", item.name), Type: "success"}
+				}
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case projectsScannedMsg:
+		m.state = projectsStateList
+		m.scannedAt = msg.cache.ScannedAt
+		items := make([]list.Item, len(msg.cache.Repos))
+		for i, repo := range msg.cache.Repos {
+			items[i] = projectItem{name: filepath.Base(repo), path: repo}
+		}
+		return m, m.list.SetItems(items)
+
+	case projectsErrorMsg:
+		m.state = projectsStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	if m.state == projectsStateList {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *ProjectsModel) View() string {
+	var b strings.Builder
+
+	switch m.state {
+	case projectsStateScanning:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Folder + " Projects"))
+		b.WriteString("\n\n")
+		b.WriteString(m.spinner.View() + " Scanning " + m.root + "...")
+
+	case projectsStateList:
+		b.WriteString(m.list.View())
+		b.WriteString("\n")
+		scanned := lipgloss.NewStyle().Foreground(styles.TextMuted).Render("scanned " + m.scannedAt.Format("Jan 2 15:04"))
+		b.WriteString(styles.HelpStyle.Render("enter: switch • /: filter • r: rescan • esc: back  ") + scanned)
+
+	case projectsStateError:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Folder + " Projects"))
+		b.WriteString("\n\n")
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
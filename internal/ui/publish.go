@@ -1,15 +1,19 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/0mykull/gitty/internal/config"
 	"github.com/0mykull/gitty/internal/git"
@@ -45,6 +49,23 @@ type PublishModel struct {
 	err         error
 	repoURL     string
 
+	// Last line of gh output while publishing
+	progress string
+
+	// Bundled .gitignore to write before the first commit, "" for none
+	gitignore    string
+	hasGitignore bool
+
+	// Optional gh repo create flags, toggled on the confirm screen
+	disableIssues bool
+	disableWiki   bool
+
+	// Set when publishing worked but the tag did not make it to GitHub
+	warning string
+
+	// Transient feedback after copying the URL
+	copied string
+
 	// Text inputs for step-by-step
 	nameInput textinput.Model
 	descInput textinput.Model
@@ -53,9 +74,7 @@ type PublishModel struct {
 
 // NewPublishModel creates a new publish model
 func NewPublishModel(cfg *config.Config) *PublishModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = styles.SpinnerStyle
+	s := newSpinner()
 
 	// Get default repo name from directory
 	defaultName := git.GetRepoName()
@@ -99,13 +118,16 @@ func (m *PublishModel) checkRepo() tea.Msg {
 	// Check if we're in a git repo
 	if !git.IsRepo() {
 		// Initialize git
-		if err := git.Init(); err != nil {
+		if err := git.Init(m.cfg.Git.DefaultBranch); err != nil {
 			return publishErrorMsg{fmt.Errorf("failed to initialize git: %w", err)}
 		}
 	}
 
 	// Get current branch
 	branch, _ := git.GetBranch()
+	if branch == "" {
+		branch = m.cfg.Git.DefaultBranch
+	}
 	if branch == "" {
 		branch = "main"
 	}
@@ -125,7 +147,8 @@ type publishRepoCheckedMsg struct {
 }
 
 type publishErrorMsg struct{ err error }
-type publishDoneMsg struct{ url string }
+type publishDoneMsg struct{ url, warning string }
+type publishCopiedClearMsg struct{}
 
 func (m *PublishModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -140,6 +163,39 @@ func (m *PublishModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.state != publishStateForm {
 				return m.handleEnter()
 			}
+		case "i":
+			if m.state == publishStateConfirm {
+				m.disableIssues = !m.disableIssues
+				return m, nil
+			}
+		case "c":
+			if m.state == publishStateDone && m.repoURL != "" {
+				if err := clipboard.WriteAll(m.repoURL); err != nil {
+					m.copied = fmt.Sprintf("Could not copy: %v", err)
+				} else {
+					m.copied = "Copied!"
+				}
+				return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return publishCopiedClearMsg{} })
+			}
+		case "n":
+			// Pick another name after gh found this one taken
+			if m.state == publishStateError && errors.Is(m.err, git.ErrRepoExists) {
+				m.err = nil
+				m.state = publishStateForm
+				return m, m.initForm()
+			}
+		case "e":
+			if m.state == publishStateError && errors.Is(m.err, git.ErrRepoExists) {
+				m.err = nil
+				m.state = publishStateWorking
+				m.progress = ""
+				return m, tea.Batch(m.spinner.Tick, m.useExisting)
+			}
+		case "w":
+			if m.state == publishStateConfirm {
+				m.disableWiki = !m.disableWiki
+				return m, nil
+			}
 		}
 
 	case spinner.TickMsg:
@@ -167,14 +223,21 @@ func (m *PublishModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case publishDoneMsg:
+		// Stay on the done screen so the URL can be copied
 		m.state = publishStateDone
 		m.repoURL = msg.url
-		return m, func() tea.Msg {
-			return ReturnToMenuMsg{
-				Message: fmt.Sprintf("Published to %s", msg.url),
-				Type:    "success",
-			}
+		m.warning = msg.warning
+		return m, nil
+
+	case publishProgressMsg:
+		if m.state == publishStateWorking {
+			m.progress = msg.line
 		}
+		return m, waitForPublishProgress(msg.progress)
+
+	case publishCopiedClearMsg:
+		m.copied = ""
+		return m, nil
 	}
 
 	// Update form if in form state
@@ -185,6 +248,7 @@ func (m *PublishModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if m.form.State == huh.StateCompleted {
+			m.tagName = strings.TrimSpace(m.tagName)
 			m.state = publishStateConfirm
 			return m, nil
 		}
@@ -197,6 +261,7 @@ func (m *PublishModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *PublishModel) initForm() tea.Cmd {
 	defaultName := git.GetRepoName()
+	m.hasGitignore = hasGitignore()
 
 	m.form = huh.NewForm(
 		huh.NewGroup(
@@ -227,7 +292,28 @@ func (m *PublishModel) initForm() tea.Cmd {
 				Title("Add version tag?").
 				Value(&m.addTag),
 		),
-	).WithTheme(huh.ThemeCharm())
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Tag name").
+				Value(&m.tagName).
+				Placeholder(m.tagInput.Placeholder).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("tag name cannot be empty")
+					}
+					return nil
+				}),
+		).WithHideFunc(func() bool { return !m.addTag }),
+
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Add a .gitignore?").
+				Description("Written before the first commit").
+				Options(gitignoreOptions()...).
+				Value(&m.gitignore),
+		).WithHideFunc(func() bool { return m.hasGitignore }),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
 
 	// Set defaults
 	if m.repoName == "" {
@@ -244,7 +330,8 @@ func (m *PublishModel) handleEnter() (tea.Model, tea.Cmd) {
 	switch m.state {
 	case publishStateConfirm:
 		m.state = publishStateWorking
-		return m, m.doPublish
+		m.progress = ""
+		return m, m.startPublish()
 
 	case publishStateError:
 		return m, func() tea.Msg {
@@ -268,7 +355,7 @@ func (m *PublishModel) pushToRemote() tea.Msg {
 	status, _ := git.GetStatus()
 	if status.HasUnstaged || status.HasUntracked {
 		git.AddAll()
-		git.Commit("Update")
+		git.Commit("Update", git.CommitOptions{Sign: m.cfg.Git.SignCommits})
 	}
 
 	// Push
@@ -277,47 +364,102 @@ func (m *PublishModel) pushToRemote() tea.Msg {
 	}
 
 	url, _ := git.GetGitHubURL()
-	return publishDoneMsg{url}
+	return publishDoneMsg{url, m.pushTag()}
+}
+
+// pushTag pushes the tag chosen in the form, returning a warning on failure
+// since the repository itself is already up
+func (m *PublishModel) pushTag() string {
+	if !m.addTag || m.tagName == "" {
+		return ""
+	}
+	if err := git.PushTags(); err != nil {
+		return fmt.Sprintf("Tag %s was not pushed: %v", m.tagName, err)
+	}
+	return ""
+}
+
+// useExisting adds the repository gh refused to create as origin and pushes
+// to it, for projects that were published before
+func (m *PublishModel) useExisting() tea.Msg {
+	url, err := git.GitHubRepoURL(m.repoName)
+	if err != nil {
+		return publishErrorMsg{err}
+	}
+	if err := git.AddRemote("origin", url); err != nil {
+		return publishErrorMsg{fmt.Errorf("failed to add origin: %w", err)}
+	}
+	return m.pushToRemote()
+}
+
+// startPublish runs the publish steps in the background, streaming gh's output
+func (m *PublishModel) startPublish() tea.Cmd {
+	progress := make(chan string, 64)
+	publish := func() tea.Msg {
+		return m.doPublish(progress)
+	}
+	return tea.Batch(publish, waitForPublishProgress(progress))
+}
+
+// waitForPublishProgress delivers the next line of gh output
+func waitForPublishProgress(progress <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-progress
+		if !ok {
+			return nil
+		}
+		return publishProgressMsg{line, progress}
+	}
+}
+
+type publishProgressMsg struct {
+	line     string
+	progress <-chan string
 }
 
-func (m *PublishModel) doPublish() tea.Msg {
+func (m *PublishModel) doPublish(progress chan string) tea.Msg {
+	// gh closes progress once it exits, so every early return has to as well
+	fail := func(err error) tea.Msg {
+		close(progress)
+		return publishErrorMsg{err}
+	}
+
 	// Configure git user if specified
 	if m.cfg.Git.UserName != "" && m.cfg.Git.UserEmail != "" {
 		git.SetUser(m.cfg.Git.UserName, m.cfg.Git.UserEmail)
 	}
 
+	if m.gitignore != "" && !m.hasGitignore {
+		if err := writeGitignore(m.gitignore); err != nil {
+			return fail(fmt.Errorf("failed to write .gitignore: %w", err))
+		}
+	}
+
 	// Stage all changes
 	if err := git.AddAll(); err != nil {
-		return publishErrorMsg{fmt.Errorf("failed to stage changes: %w", err)}
+		return fail(fmt.Errorf("failed to stage changes: %w", err))
 	}
 
 	// Check if there are changes to commit
 	status, _ := git.GetStatus()
 	if status.HasStaged {
-		if err := git.Commit(m.commitMsg); err != nil {
-			return publishErrorMsg{fmt.Errorf("failed to commit: %w", err)}
+		if err := git.Commit(m.commitMsg, git.CommitOptions{Sign: m.cfg.Git.SignCommits}); err != nil {
+			return fail(fmt.Errorf("failed to commit: %w", err))
 		}
 	}
 
-	// Add tag if requested
-	if m.addTag && m.tagName != "" {
+	// Add tag if requested, it is pushed once the repo exists. A retry after
+	// the repo name was taken finds the tag from the first attempt.
+	var warning string
+	if m.addTag && m.tagName != "" && !git.TagExists(m.tagName) {
 		if err := git.Tag(m.tagName); err != nil {
-			// Tag might already exist, ignore error
+			warning = fmt.Sprintf("Could not create tag %s: %v", m.tagName, err)
 		}
 	}
 
 	// Create GitHub repo using gh CLI
-	args := []string{"repo", "create", m.repoName, "--" + m.visibility, "--source=.", "--remote=origin", "--push"}
-	if m.description != "" {
-		args = append(args, fmt.Sprintf("--description=%s", m.description))
-	}
-
-	cmd := exec.Command("gh", args...)
-	cmd.Dir, _ = os.Getwd()
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return publishErrorMsg{fmt.Errorf("gh cli error: %s - %w", string(output), err)}
+	if err := git.RunGhStreaming(m.ghArgs(), progress); err != nil {
+		return publishErrorMsg{err}
 	}
 
 	// Get the URL
@@ -331,7 +473,37 @@ func (m *PublishModel) doPublish() tea.Msg {
 		url = fmt.Sprintf("https://github.com/%s/%s", user, m.repoName)
 	}
 
-	return publishDoneMsg{url}
+	if warning == "" {
+		warning = m.pushTag()
+	}
+	return publishDoneMsg{url, warning}
+}
+
+// ghArgs builds the gh repo create arguments from the form and toggles
+func (m *PublishModel) ghArgs() []string {
+	args := []string{"repo", "create", m.repoName, "--" + m.visibility, "--source=.", "--remote=origin", "--push"}
+	if m.description != "" {
+		args = append(args, fmt.Sprintf("--description=%s", m.description))
+	}
+	if m.disableIssues {
+		args = append(args, "--disable-issues")
+	}
+	if m.disableWiki {
+		args = append(args, "--disable-wiki")
+	}
+	return args
+}
+
+// renderGhCommand shows the gh command line with arguments quoted where needed
+func (m *PublishModel) renderGhCommand() string {
+	parts := []string{"gh"}
+	for _, arg := range m.ghArgs() {
+		if strings.ContainsAny(arg, " \t\"'") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
 }
 
 func (m *PublishModel) View() string {
@@ -364,36 +536,77 @@ func (m *PublishModel) View() string {
 		if m.addTag {
 			info = append(info, fmt.Sprintf("  %s Tag: %s", styles.Icons.Star, m.tagName))
 		}
+		if m.gitignore != "" && !m.hasGitignore {
+			info = append(info, fmt.Sprintf("  %s .gitignore: %s", styles.Icons.File, m.gitignore))
+		}
 
 		b.WriteString(strings.Join(info, "\n"))
 		b.WriteString("\n\n")
-		b.WriteString(styles.HelpStyle.Render("Press enter to publish, esc to cancel"))
+
+		checkbox := func(on bool) string {
+			if on {
+				return styles.SuccessStyle.Render("[x]")
+			}
+			return lipgloss.NewStyle().Foreground(styles.TextMuted).Render("[ ]")
+		}
+		b.WriteString(fmt.Sprintf("  %s --disable-issues  (i)\n", checkbox(m.disableIssues)))
+		b.WriteString(fmt.Sprintf("  %s --disable-wiki    (w)\n", checkbox(m.disableWiki)))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(styles.TextMuted).Render("  $ " + m.renderGhCommand()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("i/w: toggle flags • enter: publish • esc: cancel"))
 
 	case publishStateWorking:
 		b.WriteString(m.spinner.View() + " Publishing to GitHub...")
 		b.WriteString("\n")
 		b.WriteString(styles.HelpStyle.Render("Creating repository and pushing code..."))
+		if m.progress != "" {
+			b.WriteString("\n\n")
+			b.WriteString(lipgloss.NewStyle().Foreground(styles.TextMuted).Render(m.progress))
+		}
 
 	case publishStateDone:
 		b.WriteString(styles.RenderSuccess("Published successfully!"))
 		b.WriteString("\n\n")
 		b.WriteString(fmt.Sprintf("  %s %s\n", styles.Icons.Open, m.repoURL))
 		b.WriteString("\n")
-		b.WriteString(styles.HelpStyle.Render("Press enter to continue"))
+		if m.warning != "" {
+			b.WriteString(styles.RenderWarning(m.warning))
+			b.WriteString("\n\n")
+		}
+		if m.copied != "" {
+			b.WriteString(styles.RenderInfo(m.copied))
+			b.WriteString("\n\n")
+		}
+		b.WriteString(styles.HelpStyle.Render("c: copy URL • enter: continue"))
 
 	case publishStateError:
 		b.WriteString(styles.RenderError(fmt.Sprintf("Error: %v", m.err)))
 		b.WriteString("\n\n")
 
-		// Check for common issues
-		if strings.Contains(m.err.Error(), "gh") {
-			b.WriteString(styles.WarningStyle.Render("Make sure you have the GitHub CLI (gh) installed and authenticated."))
-			b.WriteString("\n")
-			b.WriteString(styles.HelpStyle.Render("Run: gh auth login"))
+		if errors.Is(m.err, git.ErrRepoExists) {
+			b.WriteString(styles.HelpStyle.Render("n: choose another name • e: push to the existing " + m.repoName + " • enter: back"))
+			break
 		}
+
+		// Check for common issues
+		b.WriteString(renderGhHint(m.err))
 		b.WriteString("\n")
 		b.WriteString(styles.HelpStyle.Render("Press enter to go back"))
 	}
 
 	return b.String()
 }
+
+// renderGhHint explains how to set up the GitHub CLI when an error came from gh
+func renderGhHint(err error) string {
+	if errors.Is(err, git.ErrGhAuth) {
+		return styles.WarningStyle.Render("The GitHub CLI needs to be logged in again.") +
+			"\n" + styles.HelpStyle.Render("Run: gh auth login (or gh auth refresh)")
+	}
+	if !strings.Contains(err.Error(), "gh") {
+		return ""
+	}
+	return styles.WarningStyle.Render("Make sure you have the GitHub CLI (gh) installed and authenticated.") +
+		"\n" + styles.HelpStyle.Render("Run: gh auth login")
+}
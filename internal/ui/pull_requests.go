@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// prItem implements list.Item
+type prItem struct {
+	pr git.PullRequest
+}
+
+func (i prItem) FilterValue() string { return fmt.Sprintf("#%d %s", i.pr.Number, i.pr.Title) }
+
+// prDelegate renders a pull request with its branch and author
+type prDelegate struct{}
+
+func (d prDelegate) Height() int                             { return 1 }
+func (d prDelegate) Spacing() int                            { return 0 }
+func (d prDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d prDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(prItem)
+	if !ok {
+		return
+	}
+
+	number := lipgloss.NewStyle().Foreground(styles.Blue).Render(fmt.Sprintf("#%-5d", i.pr.Number))
+	meta := lipgloss.NewStyle().Foreground(styles.TextMuted).Render(fmt.Sprintf("  %s • %s", i.pr.Branch, i.pr.Author.Login))
+
+	var line string
+	if index == m.Index() {
+		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
+		title := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true).Render(i.pr.Title)
+		line = arrow + number + title + meta
+	} else {
+		title := lipgloss.NewStyle().Foreground(styles.TextPrimary).Render(i.pr.Title)
+		line = "     " + number + title + meta
+	}
+
+	fmt.Fprint(w, line)
+}
+
+type pullRequestsState int
+
+const (
+	pullRequestsStateLoading pullRequestsState = iota
+	pullRequestsStateList
+	pullRequestsStateWorking
+	pullRequestsStateError
+)
+
+// PullRequestsModel lists open pull requests and checks one out for review
+type PullRequestsModel struct {
+	state   pullRequestsState
+	spinner spinner.Model
+	list    list.Model
+	picked  git.PullRequest
+	err     error
+}
+
+// NewPullRequestsModel creates a new pull request picker
+func NewPullRequestsModel(width, height int) *PullRequestsModel {
+	s := newSpinner()
+
+	l := list.New(nil, prDelegate{}, width, max(height-4, 5))
+	l.Title = "Pull Requests"
+	l.Styles.Title = styles.TitleStyle
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.DisableQuitKeybindings()
+
+	return &PullRequestsModel{
+		state:   pullRequestsStateLoading,
+		spinner: s,
+		list:    l,
+	}
+}
+
+func (m *PullRequestsModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadPullRequests,
+	)
+}
+
+func (m *PullRequestsModel) loadPullRequests() tea.Msg {
+	prs, err := git.ListPullRequests()
+	if err != nil {
+		return pullRequestsErrorMsg{err}
+	}
+	return pullRequestsLoadedMsg{prs}
+}
+
+func (m *PullRequestsModel) doCheckout() tea.Msg {
+	if err := git.CheckoutPullRequest(m.picked.Number); err != nil {
+		return pullRequestsErrorMsg{err}
+	}
+	return ReturnToMenuMsg{
+		Message: fmt.Sprintf("Checked out PR #%d (%s)", m.picked.Number, m.picked.Branch),
+		Type:    "success",
+	}
+}
+
+type pullRequestsLoadedMsg struct{ prs []git.PullRequest }
+type pullRequestsErrorMsg struct{ err error }
+
+func (m *PullRequestsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, max(msg.Height-4, 5))
+
+	case tea.KeyMsg:
+		// Let the list handle typing a filter and clearing it with esc
+		if m.list.SettingFilter() || (msg.String() == "esc" && m.list.IsFiltered()) {
+			break
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			if m.state == pullRequestsStateWorking {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "enter":
+			if m.state != pullRequestsStateList {
+				break
+			}
+			if item, ok := m.list.SelectedItem().(prItem); ok {
+				m.picked = item.pr
+				m.state = pullRequestsStateWorking
+				return m, tea.Batch(m.spinner.Tick, m.doCheckout)
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case pullRequestsLoadedMsg:
+		if len(msg.prs) == 0 {
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "No open pull requests", Type: "info"}
+			}
+		}
+		m.state = pullRequestsStateList
+		items := make([]list.Item, len(msg.prs))
+		for i, pr := range msg.prs {
+			items[i] = prItem{pr}
+		}
+		return m, m.list.SetItems(items)
+
+	case pullRequestsErrorMsg:
+		m.state = pullRequestsStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	if m.state == pullRequestsStateList {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *PullRequestsModel) View() string {
+	var b strings.Builder
+
+	switch m.state {
+	case pullRequestsStateLoading:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Branch + " Pull Requests"))
+		b.WriteString("\n\n")
+		b.WriteString(m.spinner.View() + " Fetching open pull requests...")
+
+	case pullRequestsStateList:
+		b.WriteString(m.list.View())
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("enter: check out • /: filter • esc: back"))
+
+	case pullRequestsStateWorking:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Branch + " Pull Requests"))
+		b.WriteString("\n\n")
+		b.WriteString(m.spinner.View() + fmt.Sprintf(" Checking out #%d...", m.picked.Number))
+
+	case pullRequestsStateError:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Branch + " Pull Requests"))
+		b.WriteString("\n\n")
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		if hint := renderGhHint(m.err); hint != "" {
+			b.WriteString(hint)
+			b.WriteString("\n\n")
+		}
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
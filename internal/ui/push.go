@@ -0,0 +1,310 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+type pushState int
+
+const (
+	pushStateForm pushState = iota
+	pushStatePushing
+	pushStateError
+)
+
+type pushMode int
+
+const (
+	pushModePick        pushMode = iota // choose remote and branch
+	pushModeSetUpstream                 // push with -u after a "no upstream" failure
+	pushModeDiverged                    // the remote has commits we don't
+)
+
+// Choices offered when the remote is ahead
+const (
+	divergedPullPush = "pull"
+	divergedForce    = "force"
+	divergedCancel   = "cancel"
+)
+
+// PushModel picks which remote and branch to push to when there is more than
+// one remote, and handles a first push or a remote that moved ahead
+type PushModel struct {
+	cfg       *config.Config
+	mode      pushMode
+	state     pushState
+	spinner   spinner.Model
+	form      *huh.Form
+	remotes   map[string]string
+	remote    string
+	branch    string
+	behind    int  // commits on the upstream we don't have, when known
+	rejected  bool // a push already failed, rather than being warned ahead of time
+	confirmed bool
+	choice    string
+	err       error
+}
+
+// NewPushModel creates a push target picker for the given remotes
+func NewPushModel(cfg *config.Config, remotes map[string]string) *PushModel {
+	s := newSpinner()
+
+	branch, _ := git.GetBranch()
+
+	// Preselect the remote the branch already tracks, else origin
+	remote := "origin"
+	if upstream, err := git.Upstream(); err == nil {
+		if name, _, ok := strings.Cut(upstream, "/"); ok {
+			remote = name
+		}
+	}
+
+	return &PushModel{
+		cfg:     cfg,
+		mode:    pushModePick,
+		state:   pushStateForm,
+		spinner: s,
+		remotes: remotes,
+		remote:  remote,
+		branch:  branch,
+	}
+}
+
+// NewSetUpstreamModel offers to push the current branch to origin and track it
+func NewSetUpstreamModel(cfg *config.Config) *PushModel {
+	m := NewPushModel(cfg, nil)
+	m.mode = pushModeSetUpstream
+	m.remote = "origin"
+	return m
+}
+
+// NewDivergedPushModel offers to pull first or force when the upstream is
+// ahead, either warned from status (behind > 0) or after a rejected push
+func NewDivergedPushModel(cfg *config.Config, behind int, rejected bool) *PushModel {
+	m := NewPushModel(cfg, nil)
+	m.mode = pushModeDiverged
+	m.behind = behind
+	m.rejected = rejected
+	m.choice = divergedPullPush
+	return m
+}
+
+// pushNoUpstreamMsg asks the menu to offer setting the upstream
+type pushNoUpstreamMsg struct{}
+
+// pushRejectedMsg asks the menu to offer ways past a non-fast-forward push
+type pushRejectedMsg struct{}
+
+// pushPickMsg asks the menu to open the push target picker
+type pushPickMsg struct {
+	remotes map[string]string
+}
+
+func (m *PushModel) Init() tea.Cmd {
+	var group *huh.Group
+	switch m.mode {
+	case pushModeSetUpstream:
+		description := "This runs git push -u origin " + m.branch
+		if !git.HasRemote("origin") {
+			description = "There is no origin remote yet, add one from Remotes (O) first"
+		}
+		group = huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("%s has no upstream. Push it to origin and track it?", m.branch)).
+				Description(description).
+				Affirmative("Push").
+				Negative("Cancel").
+				Value(&m.confirmed),
+		)
+
+	case pushModeDiverged:
+		title := "The remote has commits you don't have"
+		if m.behind > 0 {
+			title = fmt.Sprintf("%s is %d commit(s) behind its upstream", m.branch, m.behind)
+		}
+		description := "A plain push would be rejected"
+		if m.rejected {
+			description = "The push was rejected as non-fast-forward"
+		}
+		group = huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(title).
+				Description(description).
+				Options(
+					huh.NewOption("Pull, then push", divergedPullPush),
+					huh.NewOption("Force push (--force-with-lease)", divergedForce),
+					huh.NewOption("Cancel", divergedCancel),
+				).
+				Value(&m.choice),
+		)
+
+	default:
+		names := make([]string, 0, len(m.remotes))
+		for name := range m.remotes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		options := make([]huh.Option[string], len(names))
+		for i, name := range names {
+			options[i] = huh.NewOption(fmt.Sprintf("%s (%s)", name, m.remotes[name]), name)
+		}
+
+		group = huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Push to remote").
+				Options(options...).
+				Value(&m.remote),
+
+			huh.NewInput().
+				Title("Branch").
+				Value(&m.branch).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("branch cannot be empty")
+					}
+					return nil
+				}),
+		)
+	}
+
+	m.form = huh.NewForm(group).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	return tea.Batch(
+		m.spinner.Tick,
+		m.form.Init(),
+	)
+}
+
+type pushErrorMsg struct{ err error }
+
+func (m *PushModel) doPush() tea.Msg {
+	branch := strings.TrimSpace(m.branch)
+
+	switch m.mode {
+	case pushModeSetUpstream:
+		if err := git.PushWithUpstream(m.remote, branch); err != nil {
+			return pushErrorMsg{err}
+		}
+		return ReturnToMenuMsg{Message: fmt.Sprintf("Pushed %s and set upstream to %s/%s", branch, m.remote, branch), Type: "success"}
+
+	case pushModeDiverged:
+		if m.choice == divergedForce {
+			if err := git.PushForceWithLease(); err != nil {
+				if errors.Is(err, git.ErrDryRun) {
+					return ReturnToMenuMsg{Message: err.Error(), Type: "info"}
+				}
+				return pushErrorMsg{err}
+			}
+			return ReturnToMenuMsg{Message: "Force pushed (with lease)", Type: "success"}
+		}
+		if err := git.Pull(); err != nil {
+			return pushErrorMsg{fmt.Errorf("pull failed, nothing was pushed: %w", err)}
+		}
+		if err := git.Push(); err != nil {
+			return pushErrorMsg{err}
+		}
+		return ReturnToMenuMsg{Message: "Pulled and pushed", Type: "success"}
+	}
+
+	if err := git.PushTo(m.remote, branch); err != nil {
+		return pushErrorMsg{err}
+	}
+	return ReturnToMenuMsg{Message: fmt.Sprintf("Pushed %s to %s", branch, m.remote), Type: "success"}
+}
+
+func (m *PushModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "esc" {
+			if m.state == pushStatePushing {
+				git.CancelRunning()
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case pushErrorMsg:
+		m.state = pushStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	// Update form
+	if m.state == pushStateForm && m.form != nil {
+		form, cmd := m.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.form = f
+		}
+
+		if m.form.State == huh.StateCompleted {
+			cancelled := (m.mode == pushModeSetUpstream && !m.confirmed) ||
+				(m.mode == pushModeDiverged && m.choice == divergedCancel)
+			if cancelled {
+				return m, func() tea.Msg {
+					return ReturnToMenuMsg{Message: "", Type: ""}
+				}
+			}
+			m.state = pushStatePushing
+			return m, tea.Batch(m.spinner.Tick, m.doPush)
+		}
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *PushModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Push + " Push"))
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case pushStateForm:
+		if m.form != nil {
+			b.WriteString(m.form.View())
+		}
+
+	case pushStatePushing:
+		switch {
+		case m.mode == pushModeDiverged && m.choice == divergedForce:
+			b.WriteString(m.spinner.View() + " Force pushing with lease...")
+		case m.mode == pushModeDiverged:
+			b.WriteString(m.spinner.View() + " Pulling, then pushing...")
+		default:
+			b.WriteString(m.spinner.View() + fmt.Sprintf(" Pushing %s to %s...", strings.TrimSpace(m.branch), m.remote))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("esc: cancel"))
+
+	case pushStateError:
+		b.WriteString(styles.RenderError("Push failed"))
+		b.WriteString("\n\n")
+		b.WriteString(strings.TrimSpace(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
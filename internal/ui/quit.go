@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// QuitModel asks before quitting with uncommitted changes
+type QuitModel struct {
+	cfg       *config.Config
+	status    *git.Status
+	form      *huh.Form
+	confirmed bool
+}
+
+// NewQuitModel creates a quit confirmation for a dirty working tree
+func NewQuitModel(cfg *config.Config, status *git.Status) *QuitModel {
+	return &QuitModel{cfg: cfg, status: status}
+}
+
+// isDirty reports whether status has staged, unstaged or untracked changes
+func isDirty(status *git.Status) bool {
+	return status != nil && (status.HasStaged || status.HasUnstaged || status.HasUntracked)
+}
+
+// confirmedQuitMsg tells the menu to quit
+type confirmedQuitMsg struct{}
+
+func (m *QuitModel) Init() tea.Cmd {
+	var parts []string
+	if n := len(m.status.StagedFiles); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d staged", n))
+	}
+	if n := len(m.status.ModifiedFiles); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", n))
+	}
+	if n := len(m.status.UntrackedFiles); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d untracked", n))
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Quit with uncommitted changes?").
+				Description(strings.Join(parts, ", ") + " file(s) are not committed").
+				Affirmative("Quit").
+				Negative("Stay").
+				Value(&m.confirmed),
+		),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	return m.form.Init()
+}
+
+func (m *QuitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "esc" {
+		return m, func() tea.Msg {
+			return ReturnToMenuMsg{Message: "", Type: ""}
+		}
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+
+	if m.form.State == huh.StateCompleted {
+		if m.confirmed {
+			return m, func() tea.Msg { return confirmedQuitMsg{} }
+		}
+		return m, func() tea.Msg {
+			return ReturnToMenuMsg{Message: "", Type: ""}
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *QuitModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Warning + " Quit"))
+	b.WriteString("\n\n")
+	b.WriteString(m.form.View())
+
+	return b.String()
+}
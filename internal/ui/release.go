@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 
+	"github.com/0mykull/gitty/internal/config"
 	"github.com/0mykull/gitty/internal/git"
 	"github.com/0mykull/gitty/internal/styles"
 )
@@ -15,7 +16,9 @@ import (
 type releaseState int
 
 const (
-	releaseStateForm releaseState = iota
+	releaseStateLoading releaseState = iota
+	releaseStateBump
+	releaseStateForm
 	releaseStateWorking
 	releaseStateDone
 	releaseStateError
@@ -23,6 +26,7 @@ const (
 
 // ReleaseModel handles the release creation flow
 type ReleaseModel struct {
+	cfg     *config.Config
 	state   releaseState
 	spinner spinner.Model
 	form    *huh.Form
@@ -30,21 +34,152 @@ type ReleaseModel struct {
 	message string
 	confirm bool
 	err     error
+
+	// Semver bump offered from the latest tag
+	latestTag string
+	bumpForm  *huh.Form
+	bump      string
+
+	// Commits since the latest tag, offered as release notes
+	commits      []git.CommitInfo
+	useChangelog bool
 }
 
 // NewReleaseModel creates a new release model
-func NewReleaseModel() *ReleaseModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = styles.SpinnerStyle
+func NewReleaseModel(cfg *config.Config) *ReleaseModel {
+	s := newSpinner()
 
 	return &ReleaseModel{
-		state:   releaseStateForm,
+		cfg:     cfg,
+		state:   releaseStateLoading,
 		spinner: s,
 	}
 }
 
 func (m *ReleaseModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadLatestTag,
+	)
+}
+
+func (m *ReleaseModel) loadLatestTag() tea.Msg {
+	// No tags yet is not an error, the first release starts at v0.1.0
+	tag, _ := git.LatestTag()
+	if tag == "" {
+		return releaseLatestTagMsg{}
+	}
+	commits, _ := git.CommitsSince(tag)
+	return releaseLatestTagMsg{tag, commits}
+}
+
+type releaseLatestTagMsg struct {
+	tag     string
+	commits []git.CommitInfo
+}
+
+// changelogGroups orders conventional commit types in the release notes
+var changelogGroups = []struct{ prefix, title string }{
+	{"feat", "Features"},
+	{"fix", "Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+}
+
+// changelog lists commit subjects grouped by conventional commit type,
+// with everything else under "Other"
+func changelog(commits []git.CommitInfo) string {
+	grouped := map[string][]string{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		subject := commits[i].Subject
+		group := "Other"
+		if prefix, rest, ok := strings.Cut(subject, ":"); ok {
+			kind, _, _ := strings.Cut(strings.TrimSuffix(prefix, "!"), "(")
+			for _, g := range changelogGroups {
+				if kind == g.prefix {
+					group = g.title
+					subject = strings.TrimSpace(rest)
+					break
+				}
+			}
+		}
+		grouped[group] = append(grouped[group], "- "+subject)
+	}
+
+	var sections []string
+	for _, g := range changelogGroups {
+		if lines, ok := grouped[g.title]; ok {
+			sections = append(sections, g.title+":\n"+strings.Join(lines, "\n"))
+		}
+	}
+	if lines, ok := grouped["Other"]; ok {
+		sections = append(sections, "Other:\n"+strings.Join(lines, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// bumpVersion increments the major, minor or patch part of a semver tag,
+// keeping a leading "v" and dropping any pre-release or build suffix
+func bumpVersion(tag, part string) (string, bool) {
+	prefix := ""
+	version := tag
+	if strings.HasPrefix(version, "v") {
+		prefix, version = "v", version[1:]
+	}
+	version, _, _ = strings.Cut(version, "-")
+	version, _, _ = strings.Cut(version, "+")
+
+	var major, minor, patch int
+	if n, err := fmt.Sscanf(version, "%d.%d.%d", &major, &minor, &patch); err != nil || n != 3 {
+		return "", false
+	}
+
+	switch part {
+	case "major":
+		major, minor, patch = major+1, 0, 0
+	case "minor":
+		minor, patch = minor+1, 0
+	default:
+		patch++
+	}
+	return fmt.Sprintf("%s%d.%d.%d", prefix, major, minor, patch), true
+}
+
+// initBumpForm offers patch, minor and major bumps of the latest tag
+func (m *ReleaseModel) initBumpForm() tea.Cmd {
+	var options []huh.Option[string]
+	for _, part := range []string{"patch", "minor", "major"} {
+		if next, ok := bumpVersion(m.latestTag, part); ok {
+			options = append(options, huh.NewOption(fmt.Sprintf("%-6s %s", part, next), next))
+		}
+	}
+	options = append(options, huh.NewOption("custom", ""))
+
+	fields := []huh.Field{
+		huh.NewSelect[string]().
+			Title("Next version").
+			Description("Latest tag: " + m.latestTag).
+			Options(options...).
+			Value(&m.bump),
+	}
+	if len(m.commits) > 0 {
+		m.useChangelog = true
+		fields = append(fields, huh.NewConfirm().
+			Title(fmt.Sprintf("Prefill notes with %d commit(s) since %s?", len(m.commits), m.latestTag)).
+			Value(&m.useChangelog))
+	}
+
+	m.bumpForm = huh.NewForm(
+		huh.NewGroup(fields...),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	m.state = releaseStateBump
+	return m.bumpForm.Init()
+}
+
+func (m *ReleaseModel) initForm() tea.Cmd {
+	m.state = releaseStateForm
 	m.form = huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
@@ -58,21 +193,19 @@ func (m *ReleaseModel) Init() tea.Cmd {
 					return nil
 				}),
 
-			huh.NewInput().
+			huh.NewText().
 				Title("Message (Optional)").
 				Description("Release notes or summary").
+				Lines(6).
 				Value(&m.message),
 
 			huh.NewConfirm().
 				Title("Create and Push Release?").
 				Value(&m.confirm),
 		),
-	).WithTheme(huh.ThemeCharm())
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
 
-	return tea.Batch(
-		m.spinner.Tick,
-		m.form.Init(),
-	)
+	return m.form.Init()
 }
 
 func (m *ReleaseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -89,13 +222,19 @@ func (m *ReleaseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case releaseLatestTagMsg:
+		m.latestTag = msg.tag
+		m.commits = msg.commits
+		if msg.tag == "" {
+			m.tagName = "v0.1.0"
+			return m, m.initForm()
+		}
+		return m, m.initBumpForm()
+
 	case releaseDoneMsg:
 		m.state = releaseStateDone
 		return m, func() tea.Msg {
-			return ReturnToMenuMsg{
-				Message: fmt.Sprintf("Release %s created and pushed", m.tagName),
-				Type:    "success",
-			}
+			return ReturnToMenuMsg{Message: msg.message, Type: msg.msgType}
 		}
 
 	case releaseErrorMsg:
@@ -104,6 +243,23 @@ func (m *ReleaseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if m.state == releaseStateBump && m.bumpForm != nil {
+		form, cmd := m.bumpForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.bumpForm = f
+		}
+
+		if m.bumpForm.State == huh.StateCompleted {
+			m.tagName = m.bump
+			if m.useChangelog {
+				m.message = changelog(m.commits)
+			}
+			return m, m.initForm()
+		}
+
+		return m, cmd
+	}
+
 	// Update form
 	if m.state == releaseStateForm && m.form != nil {
 		form, cmd := m.form.Update(msg)
@@ -127,12 +283,15 @@ func (m *ReleaseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-type releaseDoneMsg struct{}
+type releaseDoneMsg struct {
+	message string
+	msgType string
+}
 type releaseErrorMsg struct{ err error }
 
 func (m *ReleaseModel) doRelease() tea.Msg {
 	// Create the tag
-	if err := git.TagAnnotated(m.tagName, m.message); err != nil {
+	if err := git.TagAnnotated(m.tagName, m.message, m.cfg.Git.SignCommits); err != nil {
 		return releaseErrorMsg{fmt.Errorf("failed to create tag: %w", err)}
 	}
 
@@ -141,7 +300,15 @@ func (m *ReleaseModel) doRelease() tea.Msg {
 		return releaseErrorMsg{fmt.Errorf("failed to push tags: %w", err)}
 	}
 
-	return releaseDoneMsg{}
+	// Publish a GitHub release on top of the tag when gh is around
+	if !git.HasGh() {
+		return releaseDoneMsg{fmt.Sprintf("Tag %s pushed; no GitHub release created because gh is not installed", m.tagName), "info"}
+	}
+	if err := git.CreateGitHubRelease(m.tagName, m.message); err != nil {
+		return releaseDoneMsg{fmt.Sprintf("Tag %s pushed, but the GitHub release failed: %v", m.tagName, err), "error"}
+	}
+
+	return releaseDoneMsg{fmt.Sprintf("Release %s created and pushed", m.tagName), "success"}
 }
 
 func (m *ReleaseModel) View() string {
@@ -152,6 +319,14 @@ func (m *ReleaseModel) View() string {
 	b.WriteString("\n\n")
 
 	switch m.state {
+	case releaseStateLoading:
+		b.WriteString(m.spinner.View() + " Reading latest tag...")
+
+	case releaseStateBump:
+		if m.bumpForm != nil {
+			b.WriteString(m.bumpForm.View())
+		}
+
 	case releaseStateForm:
 		if m.form != nil {
 			b.WriteString(m.form.View())
@@ -0,0 +1,385 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// remoteItem implements list.Item
+type remoteItem struct {
+	name string
+	url  string
+}
+
+func (i remoteItem) FilterValue() string { return i.name }
+
+// remoteDelegate renders a remote with its URL
+type remoteDelegate struct{}
+
+func (d remoteDelegate) Height() int                             { return 1 }
+func (d remoteDelegate) Spacing() int                            { return 0 }
+func (d remoteDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d remoteDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(remoteItem)
+	if !ok {
+		return
+	}
+
+	url := lipgloss.NewStyle().Foreground(styles.TextMuted).Render("  " + i.url)
+
+	var line string
+	if index == m.Index() {
+		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
+		line = arrow + lipgloss.NewStyle().Foreground(styles.Pink).Bold(true).Render(i.name) + url
+	} else {
+		line = "     " + lipgloss.NewStyle().Foreground(styles.TextPrimary).Render(i.name) + url
+	}
+
+	fmt.Fprint(w, line)
+}
+
+// scpLikeURL matches the user@host:path form git accepts for SSH remotes
+var scpLikeURL = regexp.MustCompile(`^[\w.-]+@[\w.-]+:.+$`)
+
+// validateRemoteURL accepts URLs with a scheme git understands, scp-like
+// SSH addresses and existing local paths
+func validateRemoteURL(url string) error {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return fmt.Errorf("URL cannot be empty")
+	}
+	if strings.ContainsAny(url, " \t") {
+		return fmt.Errorf("URL cannot contain spaces")
+	}
+	for _, scheme := range []string{"https://", "http://", "ssh://", "git://", "file://"} {
+		if strings.HasPrefix(url, scheme) {
+			if len(url) == len(scheme) {
+				return fmt.Errorf("URL is missing a host")
+			}
+			return nil
+		}
+	}
+	if scpLikeURL.MatchString(url) {
+		return nil
+	}
+	if _, err := os.Stat(url); err == nil {
+		return nil
+	}
+	return fmt.Errorf("expected https://, ssh://, user@host:path or a local path")
+}
+
+type remotesState int
+
+const (
+	remotesStateLoading remotesState = iota
+	remotesStateList
+	remotesStateForm
+	remotesStateWorking
+	remotesStateError
+)
+
+type remoteAction int
+
+const (
+	remoteActionAdd remoteAction = iota
+	remoteActionEdit
+	remoteActionRemove
+)
+
+// RemotesModel lists remotes and adds, edits or removes them
+type RemotesModel struct {
+	cfg     *config.Config
+	state   remotesState
+	spinner spinner.Model
+	list    list.Model
+	form    *huh.Form
+	err     error
+	message string
+	msgType string
+
+	// Change being edited in the form
+	action    remoteAction
+	name      string
+	url       string
+	confirmed bool
+}
+
+// NewRemotesModel creates a new remote view
+func NewRemotesModel(cfg *config.Config, width, height int) *RemotesModel {
+	s := newSpinner()
+
+	l := list.New(nil, remoteDelegate{}, width, max(height-4, 5))
+	l.Title = "Remotes"
+	l.Styles.Title = styles.TitleStyle
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.DisableQuitKeybindings()
+
+	return &RemotesModel{
+		cfg:     cfg,
+		state:   remotesStateLoading,
+		spinner: s,
+		list:    l,
+	}
+}
+
+func (m *RemotesModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadRemotes,
+	)
+}
+
+func (m *RemotesModel) loadRemotes() tea.Msg {
+	remotes, err := git.ListRemotes()
+	if err != nil {
+		return remotesErrorMsg{err}
+	}
+
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]list.Item, len(names))
+	for i, name := range names {
+		items[i] = remoteItem{name: name, url: remotes[name]}
+	}
+	return remotesLoadedMsg{items}
+}
+
+type remotesLoadedMsg struct{ items []list.Item }
+type remotesErrorMsg struct{ err error }
+type remoteChangedMsg struct{ message, msgType string }
+
+// initForm opens the form for action, prefilled from the selected remote
+func (m *RemotesModel) initForm(action remoteAction, item remoteItem) tea.Cmd {
+	m.action = action
+	m.name = item.name
+	m.url = item.url
+	m.confirmed = false
+
+	var group *huh.Group
+	switch action {
+	case remoteActionAdd:
+		group = huh.NewGroup(
+			huh.NewInput().
+				Title("Remote name").
+				Placeholder("upstream").
+				Value(&m.name).
+				Validate(func(s string) error {
+					s = strings.TrimSpace(s)
+					if s == "" {
+						return fmt.Errorf("name cannot be empty")
+					}
+					if git.HasRemote(s) {
+						return fmt.Errorf("remote %s already exists", s)
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title("URL").
+				Placeholder("git@github.com:owner/repo.git").
+				Value(&m.url).
+				Validate(validateRemoteURL),
+		)
+	case remoteActionEdit:
+		group = huh.NewGroup(
+			huh.NewInput().
+				Title(fmt.Sprintf("URL for %s", item.name)).
+				Value(&m.url).
+				Validate(validateRemoteURL),
+		)
+	case remoteActionRemove:
+		group = huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Remove remote %s?", item.name)).
+				Description("Its remote-tracking branches are deleted too").
+				Affirmative("Remove").
+				Negative("Cancel").
+				Value(&m.confirmed),
+		)
+	}
+
+	m.form = huh.NewForm(group).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+	m.state = remotesStateForm
+	return m.form.Init()
+}
+
+func (m *RemotesModel) doChange() tea.Msg {
+	name := strings.TrimSpace(m.name)
+	url := strings.TrimSpace(m.url)
+
+	switch m.action {
+	case remoteActionAdd:
+		if err := git.AddRemote(name, url); err != nil {
+			return remoteChangedMsg{fmt.Sprintf("Failed to add %s: %v", name, err), "error"}
+		}
+		return remoteChangedMsg{fmt.Sprintf("Added %s", name), "success"}
+	case remoteActionEdit:
+		if err := git.SetRemoteURL(name, url); err != nil {
+			return remoteChangedMsg{fmt.Sprintf("Failed to update %s: %v", name, err), "error"}
+		}
+		return remoteChangedMsg{fmt.Sprintf("Updated %s", name), "success"}
+	default:
+		if err := git.RemoveRemote(name); err != nil {
+			return remoteChangedMsg{fmt.Sprintf("Failed to remove %s: %v", name, err), "error"}
+		}
+		return remoteChangedMsg{fmt.Sprintf("Removed %s", name), "success"}
+	}
+}
+
+func (m *RemotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, max(msg.Height-4, 5))
+
+	case tea.KeyMsg:
+		if m.state == remotesStateForm {
+			if msg.String() == "esc" {
+				m.state = remotesStateList
+				return m, nil
+			}
+			break
+		}
+
+		// Let the list handle typing a filter and clearing it with esc
+		if m.list.SettingFilter() || (msg.String() == "esc" && m.list.IsFiltered()) {
+			break
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			if m.state == remotesStateWorking {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "a", "n":
+			if m.state == remotesStateList {
+				return m, m.initForm(remoteActionAdd, remoteItem{})
+			}
+		case "e":
+			if item, ok := m.list.SelectedItem().(remoteItem); ok && m.state == remotesStateList {
+				return m, m.initForm(remoteActionEdit, item)
+			}
+		case "d", "x":
+			if item, ok := m.list.SelectedItem().(remoteItem); ok && m.state == remotesStateList {
+				return m, m.initForm(remoteActionRemove, item)
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case remotesLoadedMsg:
+		m.state = remotesStateList
+		return m, m.list.SetItems(msg.items)
+
+	case remoteChangedMsg:
+		m.message = msg.message
+		m.msgType = msg.msgType
+		m.state = remotesStateLoading
+		return m, m.loadRemotes
+
+	case remotesErrorMsg:
+		m.state = remotesStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	if m.state == remotesStateForm && m.form != nil {
+		form, cmd := m.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.form = f
+		}
+
+		if m.form.State == huh.StateCompleted {
+			if m.action == remoteActionRemove && !m.confirmed {
+				m.state = remotesStateList
+				return m, nil
+			}
+			m.state = remotesStateWorking
+			return m, tea.Batch(m.spinner.Tick, m.doChange)
+		}
+
+		return m, cmd
+	}
+
+	if m.state == remotesStateList {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *RemotesModel) View() string {
+	if m.state == remotesStateList {
+		var b strings.Builder
+		if len(m.list.Items()) == 0 {
+			b.WriteString(styles.TitleStyle.Render(styles.Icons.Git + " Remotes"))
+			b.WriteString("\n\n")
+			b.WriteString(styles.RenderInfo("No remotes yet, press a to add one"))
+			b.WriteString("\n\n")
+		} else {
+			b.WriteString(m.list.View())
+			b.WriteString("\n")
+		}
+		switch m.msgType {
+		case "success":
+			b.WriteString(styles.RenderSuccess(m.message) + "\n")
+		case "error":
+			b.WriteString(styles.RenderError(m.message) + "\n")
+		}
+		b.WriteString(styles.HelpStyle.Render("a: add • e: edit URL • d: remove • /: filter • esc: back"))
+		return b.String()
+	}
+
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Git + " Remotes"))
+	b.WriteString("\n\n")
+
+	switch m.state {
+	case remotesStateLoading:
+		b.WriteString(m.spinner.View() + " Loading remotes...")
+
+	case remotesStateForm:
+		if m.form != nil {
+			b.WriteString(m.form.View())
+		}
+
+	case remotesStateWorking:
+		b.WriteString(m.spinner.View() + " Updating remotes...")
+
+	case remotesStateError:
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
@@ -1,12 +1,15 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 
+	"github.com/0mykull/gitty/internal/config"
 	"github.com/0mykull/gitty/internal/git"
 	"github.com/0mykull/gitty/internal/styles"
 )
@@ -14,7 +17,8 @@ import (
 type resetState int
 
 const (
-	resetStateConfirm resetState = iota
+	resetStateLoading resetState = iota
+	resetStateConfirm
 	resetStateWorking
 	resetStateDone
 	resetStateError
@@ -22,41 +26,103 @@ const (
 
 // ResetModel handles the reset confirmation flow
 type ResetModel struct {
+	cfg       *config.Config
 	state     resetState
 	spinner   spinner.Model
 	form      *huh.Form
 	confirmed bool
 	err       error
+
+	// What the reset affects, shown in the confirmation
+	status   *git.Status
+	unpushed int
 }
 
 // NewResetModel creates a new reset confirmation model
-func NewResetModel() *ResetModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = styles.SpinnerStyle
+func NewResetModel(cfg *config.Config) *ResetModel {
+	s := newSpinner()
 
 	return &ResetModel{
-		state:     resetStateConfirm,
+		cfg:       cfg,
+		state:     resetStateLoading,
 		spinner:   s,
 		confirmed: false,
 	}
 }
 
 func (m *ResetModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.checkRisk,
+	)
+}
+
+// checkRisk gathers what a hard reset would and would not touch
+func (m *ResetModel) checkRisk() tea.Msg {
+	status, err := git.GetStatus()
+	if err != nil {
+		return resetErrorMsg{err}
+	}
+	unpushed, _ := git.UnpushedCount()
+	return resetRiskMsg{status: status, unpushed: unpushed}
+}
+
+type resetRiskMsg struct {
+	status   *git.Status
+	unpushed int
+}
+
+func (m *ResetModel) initForm() tea.Cmd {
 	m.form = huh.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
 				Title("Reset all changes?").
-				Description("This will discard all uncommitted changes (git reset --hard)").
+				Description(m.riskSummary()).
 				Affirmative("Yes, reset").
 				Negative("Cancel").
 				Value(&m.confirmed),
 		),
-	).WithTheme(huh.ThemeCharm())
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
 
 	return m.form.Init()
 }
 
+// riskSummary spells out what git reset --hard discards and what it keeps,
+// since it is often confused with dropping commits
+func (m *ResetModel) riskSummary() string {
+	lines := []string{"git reset --hard only affects uncommitted changes.", ""}
+
+	// The safety stash takes untracked files along, so nothing is lost or kept
+	stashed := m.cfg.Git.SafetyStash && !git.DryRun()
+	if stashed {
+		lines = append(lines, "Saved to a stash first, gitty shows how to restore them:")
+	} else {
+		lines = append(lines, "Will be discarded:")
+	}
+	if m.status.HasStaged || m.status.HasUnstaged {
+		lines = append(lines,
+			fmt.Sprintf("  %d staged and %d modified tracked file(s)", len(m.status.StagedFiles), len(m.status.ModifiedFiles)))
+	}
+	if stashed && m.status.HasUntracked {
+		lines = append(lines, fmt.Sprintf("  %d untracked file(s)", len(m.status.UntrackedFiles)))
+	}
+	if !m.status.HasStaged && !m.status.HasUnstaged && !(stashed && m.status.HasUntracked) {
+		lines = append(lines, "  nothing, the working tree is clean")
+	}
+
+	lines = append(lines, "", "Kept:")
+	if m.unpushed > 0 {
+		lines = append(lines, fmt.Sprintf("  all commits, including %d not pushed to any remote", m.unpushed))
+	} else {
+		lines = append(lines, "  all commits")
+	}
+	if !stashed && m.status.HasUntracked {
+		lines = append(lines, fmt.Sprintf("  %d untracked file(s)", len(m.status.UntrackedFiles)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func (m *ResetModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -71,10 +137,20 @@ func (m *ResetModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case resetRiskMsg:
+		m.status = msg.status
+		m.unpushed = msg.unpushed
+		m.state = resetStateConfirm
+		return m, m.initForm()
+
 	case resetDoneMsg:
 		m.state = resetStateDone
+		message := "Reset successful"
+		if msg.stash != "" {
+			message += fmt.Sprintf(", local changes stashed (git stash apply %s to restore)", msg.stash)
+		}
 		return m, func() tea.Msg {
-			return ReturnToMenuMsg{Message: "Reset successful", Type: "success"}
+			return ReturnToMenuMsg{Message: message, Type: "success"}
 		}
 
 	case resetErrorMsg:
@@ -106,14 +182,29 @@ func (m *ResetModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-type resetDoneMsg struct{}
+type resetDoneMsg struct{ stash string }
 type resetErrorMsg struct{ err error }
 
 func (m *ResetModel) doReset() tea.Msg {
+	var stash string
+	if m.cfg.Git.SafetyStash && !git.DryRun() {
+		ref, err := git.SafetyStash("gitty: before reset")
+		if err != nil {
+			return resetErrorMsg{fmt.Errorf("safety stash failed, nothing was changed: %w", err)}
+		}
+		stash = ref
+	}
+
 	if err := git.Reset(); err != nil {
+		if errors.Is(err, git.ErrDryRun) {
+			return ReturnToMenuMsg{Message: err.Error(), Type: "info"}
+		}
+		if stash != "" {
+			err = fmt.Errorf("%w (changes stashed, run git stash apply %s to restore)", err, stash)
+		}
 		return resetErrorMsg{err}
 	}
-	return resetDoneMsg{}
+	return resetDoneMsg{stash}
 }
 
 func (m *ResetModel) View() string {
@@ -124,6 +215,9 @@ func (m *ResetModel) View() string {
 	b.WriteString("\n\n")
 
 	switch m.state {
+	case resetStateLoading:
+		b.WriteString(m.spinner.View() + " Checking what a reset affects...")
+
 	case resetStateConfirm:
 		if m.form != nil {
 			b.WriteString(m.form.View())
@@ -1,12 +1,15 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 
+	"github.com/0mykull/gitty/internal/config"
 	"github.com/0mykull/gitty/internal/git"
 	"github.com/0mykull/gitty/internal/styles"
 )
@@ -22,6 +25,7 @@ const (
 
 // RollbackModel handles the rollback confirmation flow
 type RollbackModel struct {
+	cfg       *config.Config
 	state     rollbackState
 	spinner   spinner.Model
 	form      *huh.Form
@@ -30,12 +34,11 @@ type RollbackModel struct {
 }
 
 // NewRollbackModel creates a new rollback confirmation model
-func NewRollbackModel() *RollbackModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = styles.SpinnerStyle
+func NewRollbackModel(cfg *config.Config) *RollbackModel {
+	s := newSpinner()
 
 	return &RollbackModel{
+		cfg:       cfg,
 		state:     rollbackStateConfirm,
 		spinner:   s,
 		confirmed: false,
@@ -52,7 +55,7 @@ func (m *RollbackModel) Init() tea.Cmd {
 				Negative("Cancel").
 				Value(&m.confirmed),
 		),
-	).WithTheme(huh.ThemeCharm())
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
 
 	return m.form.Init()
 }
@@ -73,8 +76,12 @@ func (m *RollbackModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case rollbackDoneMsg:
 		m.state = rollbackStateDone
+		message := "Rollback successful"
+		if msg.stash != "" {
+			message += fmt.Sprintf(", local changes stashed (git stash apply %s to restore)", msg.stash)
+		}
 		return m, func() tea.Msg {
-			return ReturnToMenuMsg{Message: "Rollback successful", Type: "success"}
+			return ReturnToMenuMsg{Message: message, Type: "success"}
 		}
 
 	case rollbackErrorMsg:
@@ -106,14 +113,29 @@ func (m *RollbackModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-type rollbackDoneMsg struct{}
+type rollbackDoneMsg struct{ stash string }
 type rollbackErrorMsg struct{ err error }
 
 func (m *RollbackModel) doRollback() tea.Msg {
+	var stash string
+	if m.cfg.Git.SafetyStash && !git.DryRun() {
+		ref, err := git.SafetyStash("gitty: before rollback")
+		if err != nil {
+			return rollbackErrorMsg{fmt.Errorf("safety stash failed, nothing was changed: %w", err)}
+		}
+		stash = ref
+	}
+
 	if err := git.Rollback(); err != nil {
+		if errors.Is(err, git.ErrDryRun) {
+			return ReturnToMenuMsg{Message: err.Error(), Type: "info"}
+		}
+		if stash != "" {
+			err = fmt.Errorf("%w (changes stashed, run git stash apply %s to restore)", err, stash)
+		}
 		return rollbackErrorMsg{err}
 	}
-	return rollbackDoneMsg{}
+	return rollbackDoneMsg{stash}
 }
 
 func (m *RollbackModel) View() string {
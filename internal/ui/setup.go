@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// RunSetup runs the first-launch wizard and saves the answers to the config.
+// Skipping or aborting keeps the defaults already in cfg.
+func RunSetup(cfg *config.Config) error {
+	setup := true
+	userName := cfg.Git.UserName
+	userEmail := cfg.Git.UserEmail
+	provider := cfg.AI.Provider
+	apiKey := cfg.AI.APIKey
+	visibility := cfg.GitHub.DefaultVisibility
+	theme := cfg.UI.Theme
+
+	skipped := func() bool { return !setup }
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Welcome to gitty!").
+				Description("Set up a few preferences now? You can change them later in "+config.ConfigPath()).
+				Affirmative("Set up").
+				Negative("Use defaults").
+				Value(&setup),
+		),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Git user name").
+				Description("Leave empty to use your existing git config").
+				Value(&userName),
+
+			huh.NewInput().
+				Title("Git user email").
+				Description("Leave empty to use your existing git config").
+				Value(&userEmail),
+		).WithHideFunc(skipped),
+
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("AI provider").
+				Options(
+					huh.NewOption("OpenAI", "openai"),
+					huh.NewOption("Anthropic", "anthropic"),
+					huh.NewOption("Gemini", "gemini"),
+				).
+				Value(&provider),
+
+			huh.NewInput().
+				Title("API key (optional)").
+				Description("Leave empty to use the provider's environment variable").
+				EchoMode(huh.EchoModePassword).
+				Value(&apiKey),
+		).WithHideFunc(skipped),
+
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Default visibility for published repos").
+				Options(
+					huh.NewOption("Public", "public"),
+					huh.NewOption("Private", "private"),
+				).
+				Value(&visibility),
+
+			huh.NewSelect[string]().
+				Title("Theme").
+				Options(
+					huh.NewOption("Charm", "charm"),
+					huh.NewOption("Dracula", "dracula"),
+					huh.NewOption("Catppuccin", "catppuccin"),
+				).
+				Value(&theme),
+		).WithHideFunc(skipped),
+	).WithTheme(styles.ThemeFor(cfg.UI.Theme).Form())
+
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return nil
+		}
+		return err
+	}
+
+	if !setup {
+		return nil
+	}
+
+	cfg.Git.UserName = strings.TrimSpace(userName)
+	cfg.Git.UserEmail = strings.TrimSpace(userEmail)
+	if provider != cfg.AI.Provider {
+		cfg.AI.Provider = provider
+		switch provider {
+		case "anthropic":
+			cfg.AI.Model = "claude-3-5-sonnet-20241022"
+		case "gemini":
+			cfg.AI.Model = "gemini-1.5-flash"
+		}
+	}
+	cfg.AI.APIKey = strings.TrimSpace(apiKey)
+	_ = config.ResolveAPIKey(cfg)
+	cfg.GitHub.DefaultVisibility = visibility
+	cfg.UI.Theme = theme
+
+	return config.Save(cfg)
+}
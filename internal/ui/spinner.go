@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// spinnerKind is what every view shows while working, set from ui.animation_ms
+var spinnerKind = spinner.Dot
+
+// SetAnimation sets the spinner frame time in milliseconds; 0 shows a still dot
+func SetAnimation(ms int) {
+	if ms <= 0 {
+		// One frame and a tick rare enough to cost nothing
+		spinnerKind = spinner.Spinner{Frames: []string{"• "}, FPS: time.Hour}
+		return
+	}
+	spinnerKind = spinner.Dot
+	spinnerKind.FPS = time.Duration(ms) * time.Millisecond
+}
+
+// newSpinner creates a spinner in the configured style and speed
+func newSpinner() spinner.Model {
+	s := spinner.New()
+	s.Spinner = spinnerKind
+	s.Style = styles.SpinnerStyle
+	return s
+}
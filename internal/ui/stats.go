@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// statsWeeks is how many weeks of history the heatmap covers
+const statsWeeks = 8
+
+// StatsModel shows a commit heatmap and the current streak
+type StatsModel struct {
+	spinner spinner.Model
+	loaded  bool
+	start   time.Time
+	counts  []int // commits per day from start to today
+	err     error
+}
+
+// NewStatsModel creates a new commit stats view
+func NewStatsModel() *StatsModel {
+	s := newSpinner()
+
+	return &StatsModel{spinner: s}
+}
+
+func (m *StatsModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadStats,
+	)
+}
+
+// loadStats reads commit dates with a single git log call
+func (m *StatsModel) loadStats() tea.Msg {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	// Start on the Monday statsWeeks-1 weeks ago so columns line up with weeks
+	offset := (int(today.Weekday()) + 6) % 7
+	start := today.AddDate(0, 0, -offset-7*(statsWeeks-1))
+
+	dates, err := git.CommitDates(start)
+	if err != nil {
+		return statsErrorMsg{err}
+	}
+	return statsLoadedMsg{start, dailyCounts(dates, start, today)}
+}
+
+// dailyCounts buckets YYYY-MM-DD dates into one count per day from start to today
+func dailyCounts(dates []string, start, today time.Time) []int {
+	days := int(today.Sub(start).Hours()/24+0.5) + 1
+	counts := make([]int, days)
+	for _, d := range dates {
+		day, err := time.ParseInLocation("2006-01-02", d, time.Local)
+		if err != nil {
+			continue
+		}
+		i := int(day.Sub(start).Hours()/24 + 0.5)
+		if i >= 0 && i < days {
+			counts[i]++
+		}
+	}
+	return counts
+}
+
+// commitStreak counts consecutive days with commits ending today, or
+// yesterday when nothing has been committed yet today
+func commitStreak(counts []int) int {
+	i := len(counts) - 1
+	if i >= 0 && counts[i] == 0 {
+		i--
+	}
+	streak := 0
+	for ; i >= 0 && counts[i] > 0; i-- {
+		streak++
+	}
+	return streak
+}
+
+type statsLoadedMsg struct {
+	start  time.Time
+	counts []int
+}
+
+type statsErrorMsg struct{ err error }
+
+func (m *StatsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q", "enter":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		}
+
+	case spinner.TickMsg:
+		if m.loaded || m.err != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case statsLoadedMsg:
+		m.loaded = true
+		m.start = msg.start
+		m.counts = msg.counts
+
+	case statsErrorMsg:
+		m.err = msg.err
+	}
+
+	return m, nil
+}
+
+func (m *StatsModel) View() string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(styles.TitleStyle.Render(styles.Icons.Lightning + " Commit Stats"))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.err != nil:
+		b.WriteString(styles.RenderError(m.err.Error()))
+
+	case !m.loaded:
+		b.WriteString(m.spinner.View() + " Reading history...")
+
+	default:
+		b.WriteString(m.renderHeatmap())
+		b.WriteString("\n\n")
+		b.WriteString(m.renderSummary())
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(styles.HelpStyle.Render("esc: back"))
+	return b.String()
+}
+
+// renderHeatmap draws one row per weekday and one column per week
+func (m *StatsModel) renderHeatmap() string {
+	busiest := 0
+	for _, c := range m.counts {
+		busiest = max(busiest, c)
+	}
+
+	levels := []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(styles.TextMuted),
+		lipgloss.NewStyle().Foreground(styles.Purple),
+		lipgloss.NewStyle().Foreground(styles.Blue),
+		lipgloss.NewStyle().Foreground(styles.Pink),
+	}
+	label := lipgloss.NewStyle().Foreground(styles.TextMuted)
+	weekdays := []string{"Mon", "   ", "Wed", "   ", "Fri", "   ", "Sun"}
+
+	var rows []string
+	for day := 0; day < 7; day++ {
+		row := label.Render(weekdays[day] + " ")
+		for week := 0; week < statsWeeks; week++ {
+			i := week*7 + day
+			if i >= len(m.counts) {
+				break
+			}
+			level := 0
+			if m.counts[i] > 0 {
+				level = 1 + (m.counts[i]-1)*(len(levels)-1)/max(busiest, 1)
+				level = min(level, len(levels)-1)
+			}
+			row += levels[level].Render("■ ")
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (m *StatsModel) renderSummary() string {
+	total := 0
+	for _, c := range m.counts {
+		total += c
+	}
+
+	streak := commitStreak(m.counts)
+	streakText := fmt.Sprintf("%d day", streak)
+	if streak != 1 {
+		streakText += "s"
+	}
+
+	value := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true)
+	muted := lipgloss.NewStyle().Foreground(styles.TextMuted)
+
+	return muted.Render("Commits since "+m.start.Format("Jan 2")+": ") + value.Render(fmt.Sprint(total)) + "\n" +
+		muted.Render("Current streak: ") + value.Render(streakText) + "\n" +
+		muted.Render("Today: ") + value.Render(fmt.Sprint(m.counts[len(m.counts)-1]))
+}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+	"github.com/0mykull/gitty/internal/styles"
+)
+
+// tagItem implements list.Item
+type tagItem string
+
+func (i tagItem) FilterValue() string { return string(i) }
+
+// tagDelegate renders tags in the same style as the main menu
+type tagDelegate struct{}
+
+func (d tagDelegate) Height() int                             { return 1 }
+func (d tagDelegate) Spacing() int                            { return 0 }
+func (d tagDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d tagDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(tagItem)
+	if !ok {
+		return
+	}
+
+	var line string
+	if index == m.Index() {
+		arrow := lipgloss.NewStyle().Foreground(styles.Pink).Render("  " + styles.Icons.Arrow + " ")
+		line = arrow + lipgloss.NewStyle().Foreground(styles.Pink).Bold(true).Render(string(i))
+	} else {
+		line = "     " + lipgloss.NewStyle().Foreground(styles.TextPrimary).Render(string(i))
+	}
+
+	fmt.Fprint(w, line)
+}
+
+type tagsState int
+
+const (
+	tagsStateLoading tagsState = iota
+	tagsStateList
+	tagsStateConfirm
+	tagsStateWorking
+	tagsStateError
+)
+
+// TagsModel lists tags and deletes them locally or on origin
+type TagsModel struct {
+	cfg     *config.Config
+	state   tagsState
+	spinner spinner.Model
+	list    list.Model
+	form    *huh.Form
+	err     error
+	message string
+	msgType string
+
+	// Deletion being confirmed
+	tag       string
+	confirmed bool
+	remote    bool
+}
+
+// NewTagsModel creates a new tag view
+func NewTagsModel(cfg *config.Config, width, height int) *TagsModel {
+	s := newSpinner()
+
+	l := list.New(nil, tagDelegate{}, width, max(height-4, 5))
+	l.Title = "Tags"
+	l.Styles.Title = styles.TitleStyle
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.DisableQuitKeybindings()
+
+	return &TagsModel{
+		cfg:     cfg,
+		state:   tagsStateLoading,
+		spinner: s,
+		list:    l,
+	}
+}
+
+func (m *TagsModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.spinner.Tick,
+		m.loadTags,
+	)
+}
+
+func (m *TagsModel) loadTags() tea.Msg {
+	tags, err := git.ListTags()
+	if err != nil {
+		return tagsErrorMsg{err}
+	}
+	return tagsLoadedMsg{tags}
+}
+
+func (m *TagsModel) doDelete() tea.Msg {
+	if err := git.DeleteTag(m.tag, m.remote); err != nil {
+		return tagDeletedMsg{fmt.Sprintf("Failed to delete %s: %v", m.tag, err), "error"}
+	}
+	if m.remote {
+		return tagDeletedMsg{fmt.Sprintf("Deleted %s locally and on origin", m.tag), "success"}
+	}
+	return tagDeletedMsg{fmt.Sprintf("Deleted %s", m.tag), "success"}
+}
+
+type tagsLoadedMsg struct{ tags []string }
+type tagsErrorMsg struct{ err error }
+type tagDeletedMsg struct{ message, msgType string }
+
+// initDeleteForm confirms deleting the selected tag, optionally on origin too
+func (m *TagsModel) initDeleteForm(tag string) tea.Cmd {
+	m.tag = tag
+	m.confirmed = false
+	m.remote = false
+
+	fields := []huh.Field{
+		huh.NewConfirm().
+			Title(fmt.Sprintf("Delete tag %s?", tag)).
+			Affirmative("Delete").
+			Negative("Cancel").
+			Value(&m.confirmed),
+	}
+	if git.HasRemote("origin") {
+		fields = append(fields, huh.NewConfirm().
+			Title("Also delete it on origin?").
+			Description("git push --delete origin "+tag).
+			Value(&m.remote))
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(fields...),
+	).WithTheme(styles.ThemeFor(m.cfg.UI.Theme).Form())
+
+	m.state = tagsStateConfirm
+	return m.form.Init()
+}
+
+func (m *TagsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, max(msg.Height-4, 5))
+
+	case tea.KeyMsg:
+		if m.state == tagsStateConfirm {
+			if msg.String() == "esc" {
+				m.state = tagsStateList
+				return m, nil
+			}
+			break
+		}
+
+		// Let the list handle typing a filter and clearing it with esc
+		if m.list.SettingFilter() || (msg.String() == "esc" && m.list.IsFiltered()) {
+			break
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: "", Type: ""}
+			}
+		case "d", "x":
+			if m.state != tagsStateList {
+				break
+			}
+			if item, ok := m.list.SelectedItem().(tagItem); ok {
+				return m, m.initDeleteForm(string(item))
+			}
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tagsLoadedMsg:
+		if len(msg.tags) == 0 {
+			message, msgType := "No tags yet", "info"
+			if m.message != "" {
+				message, msgType = m.message, m.msgType
+			}
+			return m, func() tea.Msg {
+				return ReturnToMenuMsg{Message: message, Type: msgType}
+			}
+		}
+		m.state = tagsStateList
+		items := make([]list.Item, len(msg.tags))
+		for i, tag := range msg.tags {
+			items[i] = tagItem(tag)
+		}
+		return m, m.list.SetItems(items)
+
+	case tagDeletedMsg:
+		m.message = msg.message
+		m.msgType = msg.msgType
+		m.state = tagsStateLoading
+		return m, m.loadTags
+
+	case tagsErrorMsg:
+		m.state = tagsStateError
+		m.err = msg.err
+		return m, nil
+	}
+
+	if m.state == tagsStateConfirm && m.form != nil {
+		form, cmd := m.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.form = f
+		}
+
+		if m.form.State == huh.StateCompleted {
+			if !m.confirmed {
+				m.state = tagsStateList
+				return m, nil
+			}
+			m.state = tagsStateWorking
+			return m, tea.Batch(m.spinner.Tick, m.doDelete)
+		}
+
+		return m, cmd
+	}
+
+	if m.state == tagsStateList {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *TagsModel) View() string {
+	var b strings.Builder
+
+	switch m.state {
+	case tagsStateLoading:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Star + " Tags"))
+		b.WriteString("\n\n")
+		b.WriteString(m.spinner.View() + " Loading tags...")
+
+	case tagsStateList:
+		b.WriteString(m.list.View())
+		b.WriteString("\n")
+		switch m.msgType {
+		case "success":
+			b.WriteString(styles.RenderSuccess(m.message) + "\n")
+		case "error":
+			b.WriteString(styles.RenderError(m.message) + "\n")
+		}
+		b.WriteString(styles.HelpStyle.Render("d: delete • /: filter • esc: back"))
+
+	case tagsStateConfirm:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Star + " Tags"))
+		b.WriteString("\n\n")
+		if m.form != nil {
+			b.WriteString(m.form.View())
+		}
+
+	case tagsStateWorking:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Star + " Tags"))
+		b.WriteString("\n\n")
+		b.WriteString(m.spinner.View() + " Deleting " + m.tag + "...")
+
+	case tagsStateError:
+		b.WriteString(styles.TitleStyle.Render(styles.Icons.Star + " Tags"))
+		b.WriteString("\n\n")
+		b.WriteString(styles.RenderError(m.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.HelpStyle.Render("Press esc to go back"))
+	}
+
+	return b.String()
+}
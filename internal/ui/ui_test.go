@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/0mykull/gitty/internal/config"
+	"github.com/0mykull/gitty/internal/git"
+)
+
+func TestNewModel(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	if warnings := KeybindingWarnings(cfg); len(warnings) > 0 {
+		t.Errorf("default keybindings report %q", warnings)
+	}
+
+	m := NewModel(cfg)
+	if len(m.list.Items()) == 0 {
+		t.Fatal("menu has no items")
+	}
+	if m.View() == "" {
+		t.Error("menu renders nothing")
+	}
+}
+
+func TestHunkSummaryBeforeStaging(t *testing.T) {
+	m := NewHunkModel(80, 24)
+	m.Update(hunksLoadedMsg{[]git.Hunk{
+		{File: "a.go", Lines: []string{"@@ -1 +1 @@", "-a", "+b"}},
+		{File: "b.go", Lines: []string{"@@ -1 +1 @@", "-c", "+d"}},
+	}})
+
+	press := func(key string) {
+		t.Helper()
+		if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}); cmd != nil && m.state != hunkStateApplying {
+			t.Fatalf("%q started a command before the summary was confirmed", key)
+		}
+	}
+
+	press("y")
+	press("n")
+	if m.state != hunkStateSummary {
+		t.Fatalf("state after the last hunk = %v, want the summary", m.state)
+	}
+
+	// p revisits the last decision instead of having staged already
+	press("p")
+	if m.state != hunkStateReview || m.index != 1 {
+		t.Fatalf("state = %v, index = %d after p, want the last hunk", m.state, m.index)
+	}
+	press("y")
+	if m.state != hunkStateSummary || !m.accepted[1] {
+		t.Fatalf("state = %v, accepted = %v, want the changed decision in the summary", m.state, m.accepted)
+	}
+	if !strings.Contains(m.View(), "Stage 2 of 2 hunk(s)?") {
+		t.Errorf("summary view = %q", m.View())
+	}
+}
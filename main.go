@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -14,6 +17,13 @@ import (
 )
 
 func main() {
+	repo := flag.String("repo", "", "run in the repository at this path")
+	pick := flag.Bool("pick", false, "start by picking a repository from ui.projects_dir")
+	dryRun := flag.Bool("dry-run", false, "show what reset, rollback and force push would run instead of running them")
+	flag.Parse()
+
+	git.SetDryRun(*dryRun)
+
 	// Check dependencies
 	missing := git.CheckDeps()
 	for _, m := range missing {
@@ -25,16 +35,84 @@ func main() {
 		// fmt.Printf("%s Warning: %s not found\n", styles.Icons.Warning, m)
 	}
 
-	// Load or create config
-	cfg, err := config.EnsureConfig()
-	if err != nil {
-		fmt.Printf("%s Failed to load config: %v\n", styles.Icons.Cross, err)
+	if *repo != "" {
+		if err := os.Chdir(*repo); err != nil {
+			fmt.Printf("%s Cannot open repo: %v\n", styles.Icons.Cross, err)
+			os.Exit(1)
+		}
+	}
+
+	// Run every git command from the top level, so e.g. Stage All covers the
+	// whole repo when gitty is started from a subdirectory
+	if root, err := git.RepoRoot(); err == nil {
+		_ = os.Chdir(root)
+	}
+
+	// Load config, including the repo's own overrides. The file itself is
+	// written by first-run setup, which subcommands don't show.
+	firstRun := !config.Exists()
+	cfg, err := config.Load()
+	if errors.Is(err, config.ErrLocalConfig) || errors.Is(err, config.ErrAPIKey) {
+		// One line per problem, the rest of the config is loaded
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintf(os.Stderr, "%s %s\n", styles.Icons.Warning, line)
+		}
+	} else if errors.Is(err, config.ErrInvalidConfig) {
+		fmt.Fprintf(os.Stderr, "%s Failed to load config: %v\n", styles.Icons.Cross, err)
+		fmt.Fprintf(os.Stderr, "  Fix %s (or move it away to start from defaults) and run gitty again\n", config.ConfigPath())
+		os.Exit(1)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "%s Failed to load config: %v\n", styles.Icons.Cross, err)
 		os.Exit(1)
 	}
 
+	// Before anything else prints, subcommands included
+	styles.Icons = styles.IconsFor(cfg.UI.ShowIcons)
+
+	// Point out typos rather than let them fall back to defaults unnoticed
+	for _, err := range config.Validate(cfg) {
+		fmt.Fprintf(os.Stderr, "%s Config: %v\n", styles.Icons.Warning, err)
+	}
+
+	git.SetCommandTimeout(time.Duration(cfg.Git.CommandTimeoutMs) * time.Millisecond)
+
+	// Subcommands like `gitty push` run directly and skip the menu
+	if flag.NArg() > 0 {
+		exitOnError(runCommand(cfg, flag.Args()))
+		return
+	}
+
+	// Walk new users through the basics
+	if firstRun {
+		if err := ui.RunSetup(cfg); err != nil {
+			fmt.Printf("%s Setup failed: %v\n", styles.Icons.Warning, err)
+		}
+		// Also when setup was skipped, so it is only offered once
+		if !config.Exists() {
+			if err := config.Save(cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Cannot write config: %v\n", styles.Icons.Warning, err)
+			}
+		}
+	}
+
+	for _, warning := range ui.KeybindingWarnings(cfg) {
+		fmt.Fprintf(os.Stderr, "%s %s\n", styles.Icons.Warning, warning)
+	}
+
+	styles.Apply(styles.ThemeFor(cfg.UI.Theme))
+	ui.SetAnimation(cfg.UI.AnimationMs)
+
 	// Create and run the program
 	model := ui.NewModel(cfg)
-	p := tea.NewProgram(model)
+	if *pick {
+		model = model.WithProjectPicker()
+	}
+	var opts []tea.ProgramOption
+	if cfg.UI.Mouse {
+		// Clicks are mapped to rows, which needs the screen to ourselves
+		opts = append(opts, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(model, opts...)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("%s Error: %v\n", styles.Icons.Cross, err)